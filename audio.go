@@ -0,0 +1,169 @@
+package gradium
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// wavHeaderSize is the size of the canonical RIFF/WAVE header written by
+// PCMToWAV.
+const wavHeaderSize = 44
+
+// WAV format tags accepted by WAVToPCM.
+const (
+	wavFormatPCM        = 1
+	wavFormatExtensible = 0xFFFE
+)
+
+// PCMToWAV wraps raw little-endian PCM samples in a canonical 44-byte
+// RIFF/WAVE header.
+//
+// Example:
+//
+//	wav := gradium.PCMToWAV(result.RawData, result.SampleRate, 1, 16)
+//	os.WriteFile("output.wav", wav, 0644)
+func PCMToWAV(pcm []byte, sampleRate, channels, bitsPerSample int) []byte {
+	blockAlign := channels * bitsPerSample / 8
+
+	wav := make([]byte, wavHeaderSize, wavHeaderSize+len(pcm))
+	copy(wav[0:], "RIFF")
+	binary.LittleEndian.PutUint32(wav[4:], uint32(wavHeaderSize-8+len(pcm)))
+	copy(wav[8:], "WAVE")
+	copy(wav[12:], "fmt ")
+	binary.LittleEndian.PutUint32(wav[16:], 16)
+	binary.LittleEndian.PutUint16(wav[20:], wavFormatPCM)
+	binary.LittleEndian.PutUint16(wav[22:], uint16(channels))
+	binary.LittleEndian.PutUint32(wav[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(wav[28:], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(wav[32:], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(wav[34:], uint16(bitsPerSample))
+	copy(wav[36:], "data")
+	binary.LittleEndian.PutUint32(wav[40:], uint32(len(pcm)))
+	return append(wav, pcm...)
+}
+
+// WAVToPCM extracts the samples of a 16-bit or 24-bit PCM WAV file as 16-bit
+// little-endian PCM, the format ResamplePCM and STT expect, along with its
+// sample rate and channel count. 24-bit samples are reduced to their 16 most
+// significant bits. WAVE_FORMAT_EXTENSIBLE files are accepted when their
+// subformat is PCM; float data is rejected. Chunks other than "fmt " and
+// "data" are skipped. A data chunk whose declared size runs past the end of
+// the input, as written by streaming encoders that don't know the length up
+// front, is truncated to the bytes available. Malformed headers return a
+// ValidationError.
+func WAVToPCM(wav []byte) (pcm []byte, sampleRate, channels int, err error) {
+	if len(wav) < 12 || string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		return nil, 0, 0, wavError("missing RIFF/WAVE header")
+	}
+
+	var haveFmt bool
+	var bits uint16
+	for pos := 12; ; {
+		if len(wav)-pos < 8 {
+			return nil, 0, 0, wavError("no data chunk")
+		}
+		id := string(wav[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(wav[pos+4:]))
+		body := wav[pos+8:]
+
+		switch id {
+		case "fmt ":
+			if size < 16 || len(body) < 16 {
+				return nil, 0, 0, wavError(fmt.Sprintf("fmt chunk too short (%d bytes)", size))
+			}
+			format := binary.LittleEndian.Uint16(body[0:])
+			channels = int(binary.LittleEndian.Uint16(body[2:]))
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:]))
+			bits = binary.LittleEndian.Uint16(body[14:])
+			if format == wavFormatExtensible {
+				// The real format is the first two bytes of the SubFormat GUID
+				if size < 40 || len(body) < 40 {
+					return nil, 0, 0, wavError(fmt.Sprintf("extensible fmt chunk too short (%d bytes)", size))
+				}
+				format = binary.LittleEndian.Uint16(body[24:])
+			}
+			if format != wavFormatPCM {
+				return nil, 0, 0, wavError(fmt.Sprintf("unsupported format tag %#x, only PCM is supported", format))
+			}
+			if bits != 16 && bits != 24 {
+				return nil, 0, 0, wavError(fmt.Sprintf("unsupported bit depth %d, only 16 and 24 are supported", bits))
+			}
+			if channels == 0 || sampleRate == 0 {
+				return nil, 0, 0, wavError("fmt chunk has zero channels or sample rate")
+			}
+			haveFmt = true
+
+		case "data":
+			if !haveFmt {
+				return nil, 0, 0, wavError("data chunk before fmt chunk")
+			}
+			if size > len(body) {
+				size = len(body)
+			}
+			if bits == 24 {
+				return pcm24To16(body[:size]), sampleRate, channels, nil
+			}
+			return body[:size], sampleRate, channels, nil
+		}
+
+		if size > len(body) {
+			return nil, 0, 0, wavError(fmt.Sprintf("%q chunk size %d exceeds remaining %d bytes", id, size, len(body)))
+		}
+		// Chunks are padded to an even size.
+		pos += 8 + size + size%2
+	}
+}
+
+// pcm24To16 converts 24-bit little-endian samples to 16-bit by keeping the
+// two most significant bytes of each. A trailing partial sample is dropped.
+func pcm24To16(data []byte) []byte {
+	out := make([]byte, 0, len(data)/3*2)
+	for i := 0; i+3 <= len(data); i += 3 {
+		out = append(out, data[i+1], data[i+2])
+	}
+	return out
+}
+
+// wavError returns a ValidationError describing malformed WAV input.
+func wavError(msg string) error {
+	return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "invalid WAV: " + msg, Loc: []interface{}{"wav"}}}}
+}
+
+// ResamplePCM converts 16-bit little-endian mono PCM from fromRate to toRate
+// using linear interpolation between neighbouring samples.
+//
+// Example:
+//
+//	// Downsample 48kHz TTS output for an 8kHz telephony leg.
+//	narrow, err := gradium.ResamplePCM(result.RawData, 48000, 8000)
+func ResamplePCM(pcm []byte, fromRate, toRate int) ([]byte, error) {
+	if fromRate <= 0 || toRate <= 0 {
+		return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "sample rates must be positive", Loc: []interface{}{"sample_rate"}}}}
+	}
+	if len(pcm)%2 != 0 {
+		return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "16-bit PCM must have an even number of bytes", Loc: []interface{}{"pcm"}}}}
+	}
+	if fromRate == toRate || len(pcm) == 0 {
+		return append([]byte(nil), pcm...), nil
+	}
+
+	in := len(pcm) / 2
+	sample := func(i int) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(pcm[2*i:])))
+	}
+
+	out := int(int64(in) * int64(toRate) / int64(fromRate))
+	resampled := make([]byte, 2*out)
+	step := float64(fromRate) / float64(toRate)
+	for i := 0; i < out; i++ {
+		pos := float64(i) * step
+		j := int(pos)
+		v := sample(j)
+		if j+1 < in {
+			v += (sample(j+1) - v) * (pos - float64(j))
+		}
+		binary.LittleEndian.PutUint16(resampled[2*i:], uint16(int16(math.Round(v))))
+	}
+	return resampled, nil
+}
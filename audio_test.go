@@ -0,0 +1,244 @@
+package gradium
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// pcm16 encodes samples as 16-bit little-endian PCM.
+func pcm16(samples ...int16) []byte {
+	b := make([]byte, 2*len(samples))
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(b[2*i:], uint16(s))
+	}
+	return b
+}
+
+// wavChunk encodes a RIFF chunk, padding odd-sized bodies.
+func wavChunk(id string, body []byte) []byte {
+	b := append([]byte(id), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(b[4:], uint32(len(body)))
+	b = append(b, body...)
+	if len(body)%2 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func TestPCMToWAV(t *testing.T) {
+	pcm := pcm16(1, -1, 1000, -1000)
+	wav := PCMToWAV(pcm, 24000, 1, 16)
+
+	if len(wav) != wavHeaderSize+len(pcm) {
+		t.Fatalf("expected %d bytes, got %d", wavHeaderSize+len(pcm), len(wav))
+	}
+	if got := binary.LittleEndian.Uint32(wav[28:]); got != 48000 {
+		t.Errorf("expected byte rate 48000, got %d", got)
+	}
+	if !bytes.Equal(wav[wavHeaderSize:], pcm) {
+		t.Error("expected samples after the header")
+	}
+}
+
+func TestWAVToPCM(t *testing.T) {
+	header := PCMToWAV(nil, 16000, 2, 24)[:36]
+	samples := []byte{1, 2, 3, 4, 5, 6}
+	converted := []byte{2, 3, 5, 6}
+
+	tests := []struct {
+		name     string
+		wav      []byte
+		wantPCM  []byte
+		wantRate int
+		wantChan int
+	}{
+		{
+			name:     "16-bit round trip",
+			wav:      PCMToWAV(pcm16(1, 2, 3), 24000, 1, 16),
+			wantPCM:  pcm16(1, 2, 3),
+			wantRate: 24000,
+			wantChan: 1,
+		},
+		{
+			name:     "24-bit converted to 16-bit",
+			wav:      PCMToWAV(samples, 16000, 2, 24),
+			wantPCM:  converted,
+			wantRate: 16000,
+			wantChan: 2,
+		},
+		{
+			name:     "24-bit partial sample dropped",
+			wav:      PCMToWAV(append(append([]byte(nil), samples...), 7, 8), 16000, 2, 24),
+			wantPCM:  converted,
+			wantRate: 16000,
+			wantChan: 2,
+		},
+		{
+			name:     "extensible PCM",
+			wav:      append(append([]byte("RIFF\x00\x00\x00\x00WAVE"), wavChunk("fmt ", extensibleFmt(1))...), wavChunk("data", pcm16(1, 2))...),
+			wantPCM:  pcm16(1, 2),
+			wantRate: 24000,
+			wantChan: 1,
+		},
+		{
+			name:     "skips odd-sized chunks",
+			wav:      append(append(append([]byte(nil), header...), wavChunk("LIST", []byte("abc"))...), wavChunk("data", samples)...),
+			wantPCM:  converted,
+			wantRate: 16000,
+			wantChan: 2,
+		},
+		{
+			name:     "data size past end is truncated",
+			wav:      append(append(append([]byte(nil), header...), "data\xff\xff\xff\xff"...), samples...),
+			wantPCM:  converted,
+			wantRate: 16000,
+			wantChan: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcm, rate, channels, err := WAVToPCM(tt.wav)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(pcm, tt.wantPCM) {
+				t.Errorf("expected pcm %v, got %v", tt.wantPCM, pcm)
+			}
+			if rate != tt.wantRate || channels != tt.wantChan {
+				t.Errorf("expected %d Hz x%d, got %d Hz x%d", tt.wantRate, tt.wantChan, rate, channels)
+			}
+		})
+	}
+}
+
+// extensibleFmt returns a 16-bit mono 24kHz WAVE_FORMAT_EXTENSIBLE fmt chunk
+// body whose SubFormat GUID carries the format code subFormat.
+func extensibleFmt(subFormat uint16) []byte {
+	body := make([]byte, 40)
+	binary.LittleEndian.PutUint16(body[0:], wavFormatExtensible)
+	binary.LittleEndian.PutUint16(body[2:], 1)
+	binary.LittleEndian.PutUint32(body[4:], 24000)
+	binary.LittleEndian.PutUint32(body[8:], 48000)
+	binary.LittleEndian.PutUint16(body[12:], 2)
+	binary.LittleEndian.PutUint16(body[14:], 16)
+	binary.LittleEndian.PutUint16(body[16:], 22)
+	binary.LittleEndian.PutUint16(body[18:], 16)
+	binary.LittleEndian.PutUint32(body[20:], 4)
+	binary.LittleEndian.PutUint16(body[24:], subFormat)
+	copy(body[26:], "\x00\x00\x00\x00\x10\x00\x80\x00\x00\xaa\x00\x38\x9b\x71")
+	return body
+}
+
+func TestWAVToPCMMalformed(t *testing.T) {
+	valid := PCMToWAV(pcm16(1, 2), 24000, 1, 16)
+	withFmt := func(mutate func(fmt []byte)) []byte {
+		wav := append([]byte(nil), valid...)
+		mutate(wav[20:36])
+		return wav
+	}
+
+	tests := []struct {
+		name string
+		wav  []byte
+	}{
+		{name: "empty", wav: nil},
+		{name: "truncated header", wav: valid[:10]},
+		{name: "not RIFF", wav: append([]byte("RIFX"), valid[4:]...)},
+		{name: "no data chunk", wav: valid[:36]},
+		{name: "truncated chunk header", wav: valid[:40]},
+		{name: "fmt chunk too short", wav: append(append([]byte(nil), valid[:12]...), wavChunk("fmt ", []byte{1, 0})...)},
+		{name: "fmt size past end", wav: append(append([]byte(nil), valid[:12]...), "fmt \x10\x00\x00\x00\x01\x00"...)},
+		{name: "data before fmt", wav: append(append([]byte(nil), valid[:12]...), wavChunk("data", pcm16(1))...)},
+		{name: "unsupported bit depth", wav: withFmt(func(f []byte) { binary.LittleEndian.PutUint16(f[14:], 8) })},
+		{name: "float format", wav: withFmt(func(f []byte) { binary.LittleEndian.PutUint16(f[0:], 3) })},
+		{name: "extensible float", wav: append(append([]byte("RIFF\x00\x00\x00\x00WAVE"), wavChunk("fmt ", extensibleFmt(3))...), wavChunk("data", pcm16(1))...)},
+		{name: "extensible fmt too short", wav: append(append([]byte("RIFF\x00\x00\x00\x00WAVE"), wavChunk("fmt ", extensibleFmt(1)[:16])...), wavChunk("data", pcm16(1))...)},
+		{name: "zero channels", wav: withFmt(func(f []byte) { binary.LittleEndian.PutUint16(f[2:], 0) })},
+		{name: "oversized chunk", wav: append(append([]byte(nil), valid[:12]...), "LIST\xff\xff\xff\x7f"...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := WAVToPCM(tt.wav)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestResamplePCM(t *testing.T) {
+	tests := []struct {
+		name     string
+		pcm      []byte
+		fromRate int
+		toRate   int
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "same rate copies",
+			pcm:      pcm16(1, 2, 3),
+			fromRate: 24000,
+			toRate:   24000,
+			want:     pcm16(1, 2, 3),
+		},
+		{
+			name:     "upsample interpolates",
+			pcm:      pcm16(0, 100, -100),
+			fromRate: 8000,
+			toRate:   16000,
+			want:     pcm16(0, 50, 100, 0, -100, -100),
+		},
+		{
+			name:     "downsample",
+			pcm:      pcm16(0, 10, 20, 30, 40, 50),
+			fromRate: 48000,
+			toRate:   16000,
+			want:     pcm16(0, 30),
+		},
+		{
+			name:     "empty input",
+			fromRate: 48000,
+			toRate:   16000,
+			want:     nil,
+		},
+		{
+			name:     "odd byte count",
+			pcm:      []byte{1, 2, 3},
+			fromRate: 48000,
+			toRate:   16000,
+			wantErr:  true,
+		},
+		{
+			name:     "zero rate",
+			pcm:      pcm16(1),
+			fromRate: 0,
+			toRate:   16000,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResamplePCM(tt.pcm, tt.fromRate, tt.toRate)
+			if tt.wantErr {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package gradium
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultAPIKeyTTL is how long a key returned by the WithAPIKeyFunc function
+// is reused unless WithAPIKeyTTL is used.
+const defaultAPIKeyTTL = 5 * time.Minute
+
+// WithAPIKeyFunc makes the client fetch its API key by calling fn, for keys
+// that rotate, such as ones kept in a secrets manager. fn is called before
+// the first HTTP request or WebSocket dial and again whenever the key is
+// older than the TTL (see WithAPIKeyTTL); calls are serialized. If fn fails
+// the request fails with an AuthenticationError wrapping its error. It takes
+// precedence over WithAPIKey and GRADIUM_API_KEY.
+//
+// Example:
+//
+//	client, err := gradium.NewClient(gradium.WithAPIKeyFunc(func(ctx context.Context) (string, error) {
+//	    return secrets.Get(ctx, "gradium-api-key")
+//	}))
+func WithAPIKeyFunc(fn func(context.Context) (string, error)) ClientOption {
+	return func(c *Client) {
+		c.apiKeyFunc = fn
+	}
+}
+
+// WithAPIKeyTTL sets how long a key returned by the WithAPIKeyFunc function
+// is reused before fn is called again. Values below 1 are ignored. The
+// default is 5 minutes.
+func WithAPIKeyTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl > 0 {
+			c.apiKeyTTL = ttl
+		}
+	}
+}
+
+// apiKeyCache holds the key returned by a WithAPIKeyFunc function until it
+// expires.
+type apiKeyCache struct {
+	fetch func(context.Context) (string, error)
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	key     string
+	expires time.Time
+}
+
+// get returns the cached key, fetching a new one if it has expired.
+func (k *apiKeyCache) get(ctx context.Context) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.key != "" && time.Now().Before(k.expires) {
+		return k.key, nil
+	}
+
+	key, err := k.fetch(ctx)
+	if err != nil {
+		return "", &AuthenticationError{Message: "failed to get API key: " + err.Error(), Err: err}
+	}
+	if key == "" {
+		return "", &AuthenticationError{Message: "API key function returned an empty key"}
+	}
+	k.key = key
+	k.expires = time.Now().Add(k.ttl)
+	return key, nil
+}
+
+// cached returns the last key fetched, even if it has expired.
+func (k *apiKeyCache) cached() string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.key
+}
+
+// setAPIKey sets the x-api-key header to the client's current API key.
+func (c *Client) setAPIKey(ctx context.Context, header http.Header) error {
+	key := c.apiKey
+	if c.apiKeyCache != nil {
+		var err error
+		if key, err = c.apiKeyCache.get(ctx); err != nil {
+			return err
+		}
+	}
+	header.Set("x-api-key", key)
+	return nil
+}
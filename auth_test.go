@@ -0,0 +1,143 @@
+package gradium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithAPIKeyFunc(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wait      time.Duration
+		wantCalls int32
+		wantKeys  []string
+	}{
+		{name: "cached within TTL", wantCalls: 1, wantKeys: []string{"key-1", "key-1", "key-1"}},
+		{name: "refetched after TTL", ttl: time.Millisecond, wait: 5 * time.Millisecond, wantCalls: 3, wantKeys: []string{"key-1", "key-2", "key-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GRADIUM_API_KEY", "")
+
+			var mu sync.Mutex
+			var keys []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				keys = append(keys, r.Header.Get("x-api-key"))
+				mu.Unlock()
+				w.Write([]byte(`{"remaining_credits": 1}`))
+			}))
+			defer server.Close()
+
+			var calls atomic.Int32
+			client, err := NewClient(
+				WithBaseURL(server.URL),
+				WithAPIKeyTTL(tt.ttl),
+				WithAPIKeyFunc(func(context.Context) (string, error) {
+					return fmt.Sprintf("key-%d", calls.Add(1)), nil
+				}),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := client.APIKey(); got != "" {
+				t.Errorf("expected no key before the first request, got %q", got)
+			}
+
+			for range 3 {
+				if _, err := client.Credits.Get(context.Background()); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				time.Sleep(tt.wait)
+			}
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("expected %d key fetches, got %d", tt.wantCalls, got)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("expected keys %v, got %v", tt.wantKeys, keys)
+			}
+			if got := client.APIKey(); got != tt.wantKeys[len(tt.wantKeys)-1] {
+				t.Errorf("expected APIKey to return the last key, got %q", got)
+			}
+		})
+	}
+}
+
+func TestWithAPIKeyFunc_WebSocket(t *testing.T) {
+	headerCh := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headerCh <- r.Header.Get("x-api-key")
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithBaseURL(server.URL), WithAPIKeyFunc(func(context.Context) (string, error) {
+		return "rotated-key", nil
+	}))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if got := <-headerCh; got != "rotated-key" {
+		t.Errorf("expected x-api-key rotated-key in upgrade request, got %q", got)
+	}
+}
+
+func TestWithAPIKeyFunc_Error(t *testing.T) {
+	errSecrets := errors.New("secrets manager unavailable")
+	tests := []struct {
+		name    string
+		fn      func(context.Context) (string, error)
+		wantErr error
+	}{
+		{name: "fetch error", fn: func(context.Context) (string, error) { return "", errSecrets }, wantErr: errSecrets},
+		{name: "empty key", fn: func(context.Context) (string, error) { return "", nil }, wantErr: ErrAuthentication},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithBaseURL(server.URL), WithAPIKeyFunc(tt.fn))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			_, err := client.Voices.List(context.Background(), nil)
+			if !errors.Is(err, ErrAuthentication) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected AuthenticationError wrapping %v, got %v", tt.wantErr, err)
+			}
+			_, err = client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if !errors.Is(err, ErrAuthentication) {
+				t.Errorf("expected AuthenticationError from Stream, got %v", err)
+			}
+			if n := requests.Load(); n != 0 {
+				t.Errorf("expected no requests, got %d", n)
+			}
+		})
+	}
+}
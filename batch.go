@@ -0,0 +1,132 @@
+package gradium
+
+import (
+	"context"
+	"sync"
+)
+
+// CreateBatch synthesizes each of inputs with Create, running up to
+// opts.MaxConcurrent streams at a time. Results are sent on the returned
+// channel as each synthesis completes, so they arrive out of order; use
+// BatchTTSResult.Index to match them to inputs. The channel is closed once
+// every input has a result. Inputs not yet started when ctx is done fail with
+// ctx.Err().
+//
+// Example:
+//
+//	results, err := client.TTS.CreateBatch(ctx, inputs, gradium.BatchOptions{MaxConcurrent: 4})
+//	if err != nil {
+//	    return err
+//	}
+//	for r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("input %d: %v", r.Index, r.Err)
+//	        continue
+//	    }
+//	    os.WriteFile(fmt.Sprintf("out-%d.wav", r.Index), r.Result.RawData, 0644)
+//	}
+func (s *TTSService) CreateBatch(ctx context.Context, inputs []TTSParams, opts BatchOptions) (<-chan BatchTTSResult, error) {
+	return createBatch(ctx, s.Create, inputs, opts)
+}
+
+// CreateAsync runs Create in a new goroutine. The returned channel receives
+// exactly one result and is then closed.
+func (s *TTSService) CreateAsync(ctx context.Context, params TTSParams) (<-chan AsyncTTSResult, error) {
+	return createAsync(ctx, s.Create, params)
+}
+
+// ttsCreateFunc is the signature of TTSService.Create, shared with MockClient.
+type ttsCreateFunc func(ctx context.Context, params TTSParams) (*TTSResult, error)
+
+func createBatch(ctx context.Context, create ttsCreateFunc, inputs []TTSParams, opts BatchOptions) (<-chan BatchTTSResult, error) {
+	if opts.MaxConcurrent < 0 {
+		return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "max concurrent must not be negative", Loc: []interface{}{"max_concurrent"}}}}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	workers := opts.MaxConcurrent
+	if workers == 0 || workers > len(inputs) {
+		workers = len(inputs)
+	}
+
+	// Both channels hold every input, so workers never block on a consumer
+	// that stops reading.
+	jobs := make(chan int, len(inputs))
+	for i := range inputs {
+		jobs <- i
+	}
+	close(jobs)
+	results := make(chan BatchTTSResult, len(inputs))
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					results <- BatchTTSResult{Index: i, Err: err}
+					continue
+				}
+				result, err := create(ctx, inputs[i])
+				results <- BatchTTSResult{Index: i, Result: result, Err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results, nil
+}
+
+// forEachConcurrent calls fn(i) for every i in [0, n), running up to workers
+// calls at a time (all of them if workers < 1), and returns their errors by
+// index. Calls not yet started when ctx is done fail with ctx.Err().
+func forEachConcurrent(ctx context.Context, n, workers int, fn func(i int) error) []error {
+	if workers < 1 || workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func createAsync(ctx context.Context, create ttsCreateFunc, params TTSParams) (<-chan AsyncTTSResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan AsyncTTSResult, 1)
+	go func() {
+		defer close(ch)
+		result, err := create(ctx, params)
+		ch <- AsyncTTSResult{Result: result, Err: err}
+	}()
+	return ch, nil
+}
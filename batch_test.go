@@ -0,0 +1,205 @@
+package gradium
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newEchoTTSServer returns a TTS server that answers each stream with its
+// text as audio, recording the peak number of open streams in peak.
+func newEchoTTSServer(t *testing.T, peak *atomic.Int32) *httptest.Server {
+	t.Helper()
+	var open atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		n := open.Add(1)
+		defer open.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		var text strings.Builder
+		for {
+			var msg ttsTextMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+			text.WriteString(msg.Text)
+		}
+
+		// Hold the stream open so concurrent streams overlap.
+		time.Sleep(20 * time.Millisecond)
+		conn.WriteJSON(map[string]string{
+			"type":  "audio",
+			"audio": base64.StdEncoding.EncodeToString([]byte(text.String())),
+		})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+}
+
+func TestTTSService_CreateBatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputs        int
+		maxConcurrent int
+		wantPeak      int32
+	}{
+		{name: "limited concurrency", inputs: 6, maxConcurrent: 2, wantPeak: 2},
+		{name: "unlimited concurrency", inputs: 3, maxConcurrent: 0, wantPeak: 3},
+		{name: "no inputs", inputs: 0, maxConcurrent: 2, wantPeak: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var peak atomic.Int32
+			server := newEchoTTSServer(t, &peak)
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			inputs := make([]TTSParams, tt.inputs)
+			for i := range inputs {
+				inputs[i] = TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM, Text: strings.Repeat("x", i+1)}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			results, err := client.TTS.CreateBatch(ctx, inputs, BatchOptions{MaxConcurrent: tt.maxConcurrent})
+			if err != nil {
+				t.Fatalf("CreateBatch failed: %v", err)
+			}
+
+			seen := make(map[int]bool)
+			for r := range results {
+				if r.Err != nil {
+					t.Fatalf("input %d: unexpected error: %v", r.Index, r.Err)
+				}
+				if want := inputs[r.Index].Text; string(r.Result.RawData) != want {
+					t.Errorf("input %d: expected audio %q, got %q", r.Index, want, r.Result.RawData)
+				}
+				seen[r.Index] = true
+			}
+			if len(seen) != tt.inputs {
+				t.Errorf("expected %d results, got %d", tt.inputs, len(seen))
+			}
+			if got := peak.Load(); got != tt.wantPeak {
+				t.Errorf("expected peak of %d open streams, got %d", tt.wantPeak, got)
+			}
+		})
+	}
+}
+
+func TestTTSService_CreateBatchErrors(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		opts    BatchOptions
+		wantErr func(error) bool
+	}{
+		{
+			name: "negative max concurrent",
+			ctx:  context.Background(),
+			opts: BatchOptions{MaxConcurrent: -1},
+			wantErr: func(err error) bool {
+				var validationErr *ValidationError
+				return errors.As(err, &validationErr)
+			},
+		},
+		{
+			name:    "cancelled context",
+			ctx:     cancelled,
+			wantErr: func(err error) bool { return errors.Is(err, context.Canceled) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := client.TTS.CreateBatch(tt.ctx, []TTSParams{{Text: "Hello"}}, tt.opts)
+			if results != nil {
+				t.Error("expected nil channel")
+			}
+			if !tt.wantErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateBatchCancelledMidway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	create := func(context.Context, TTSParams) (*TTSResult, error) {
+		cancel()
+		return &TTSResult{}, nil
+	}
+
+	results, err := createBatch(ctx, create, make([]TTSParams, 3), BatchOptions{MaxConcurrent: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cancelledCount int
+	for r := range results {
+		if errors.Is(r.Err, context.Canceled) {
+			cancelledCount++
+		}
+	}
+	if cancelledCount != 2 {
+		t.Errorf("expected 2 inputs to fail with context.Canceled, got %d", cancelledCount)
+	}
+}
+
+func TestTTSService_CreateAsync(t *testing.T) {
+	var peak atomic.Int32
+	server := newEchoTTSServer(t, &peak)
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := client.TTS.CreateAsync(ctx, TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM, Text: "Hello"})
+	if err != nil {
+		t.Fatalf("CreateAsync failed: %v", err)
+	}
+
+	r := <-ch
+	if r.Err != nil {
+		t.Fatalf("unexpected error: %v", r.Err)
+	}
+	if string(r.Result.RawData) != "Hello" {
+		t.Errorf("expected audio %q, got %q", "Hello", r.Result.RawData)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after one result")
+	}
+}
@@ -3,7 +3,12 @@ package gradium
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"unicode/utf8"
 )
 
 // CreditsService handles credit balance operations.
@@ -13,20 +18,34 @@ type CreditsService struct {
 
 // Get returns the current credit balance for the authenticated user.
 func (s *CreditsService) Get(ctx context.Context) (*CreditsSummary, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.client.baseURL+"/usages/credits", nil)
+	return retry(ctx, s.client, func() (*CreditsSummary, error) {
+		return s.get(ctx)
+	})
+}
+
+// get makes a single Get request.
+func (s *CreditsService) get(ctx context.Context) (*CreditsSummary, error) {
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodGet, s.client.baseURL+"/usages/credits", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("x-api-key", s.client.apiKey)
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
 
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
-		return nil, &ConnectionError{Message: err.Error()}
+		return nil, requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if err := gunzipBody(resp); err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, handleAPIError(resp)
 	}
@@ -38,3 +57,152 @@ func (s *CreditsService) Get(ctx context.Context) (*CreditsSummary, error) {
 
 	return &credits, nil
 }
+
+// EstimateCost returns the expected credit cost of synthesizing params with
+// TTSService.Create, without synthesizing anything. It calls GET
+// /usages/estimate and, if the server has no such endpoint (404 or 405),
+// falls back to EstimateTTSCreditsLocal.
+//
+// Example:
+//
+//	estimate, err := client.Credits.EstimateCost(ctx, params)
+//	if err == nil && estimate.Credits > summary.RemainingCredits {
+//	    return errors.New("not enough credits")
+//	}
+func (s *CreditsService) EstimateCost(ctx context.Context, params TTSParams) (*CostEstimate, error) {
+	estimate, err := retry(ctx, s.client, func() (*CostEstimate, error) {
+		return s.estimateCost(ctx, params)
+	})
+	var apiErr *APIError
+	if errors.Is(err, ErrNotFound) || (errors.As(err, &apiErr) && apiErr.Status == http.StatusMethodNotAllowed) {
+		return localCostEstimate(params), nil
+	}
+	return estimate, err
+}
+
+// estimateCost makes a single EstimateCost request.
+func (s *CreditsService) estimateCost(ctx context.Context, params TTSParams) (*CostEstimate, error) {
+	modelName := resolveModelName(params.ModelName, s.client.ttsModel)
+	query := url.Values{}
+	query.Set("chars", strconv.Itoa(utf8.RuneCountInString(params.Text)))
+	query.Set("format", string(params.OutputFormat))
+	query.Set("model", modelName)
+
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodGet, s.client.baseURL+"/usages/estimate?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := gunzipBody(resp); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, handleAPIError(resp)
+	}
+
+	var estimate CostEstimate
+	if err := json.NewDecoder(resp.Body).Decode(&estimate); err != nil {
+		return nil, err
+	}
+
+	return &estimate, nil
+}
+
+// EstimateTTSCreditsLocal estimates, without contacting the server, the
+// credits TTSService.Create would consume for params. It assumes one credit
+// per character of Text, markup included, which errs on the high side, so
+// it is safe for deciding whether the remaining balance is enough.
+func EstimateTTSCreditsLocal(params TTSParams) int {
+	return utf8.RuneCountInString(params.Text)
+}
+
+// localCostEstimate is the EstimateCost fallback built from
+// EstimateTTSCreditsLocal.
+func localCostEstimate(params TTSParams) *CostEstimate {
+	return &CostEstimate{
+		Credits:    EstimateTTSCreditsLocal(params),
+		TextLength: utf8.RuneCountInString(params.Text),
+	}
+}
+
+// validateWatchInterval rejects a Watch interval that is not positive.
+func validateWatchInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "interval must be positive", Loc: []interface{}{"interval"}}}}
+	}
+	return nil
+}
+
+// Watch polls the credit balance every interval and sends each summary on the
+// returned channel, starting immediately. Transient failures (rate limits,
+// server errors and connection errors) skip a poll. Any other error is sent
+// on the error channel before both channels are closed. Cancelling ctx closes
+// both channels without sending an error. An interval below 1 sends a
+// ValidationError without polling.
+//
+// Example:
+//
+//	credits, errs := client.Credits.Watch(ctx, time.Minute)
+//	for summary := range credits {
+//	    fmt.Println(summary.RemainingCredits)
+//	}
+//	if err := <-errs; err != nil {
+//	    log.Fatal(err)
+//	}
+func (s *CreditsService) Watch(ctx context.Context, interval time.Duration) (<-chan CreditsSummary, <-chan error) {
+	summaries := make(chan CreditsSummary, 1)
+	errs := make(chan error, 1)
+
+	if err := validateWatchInterval(interval); err != nil {
+		errs <- err
+		close(errs)
+		close(summaries)
+		return summaries, errs
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(summaries)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			credits, err := s.Get(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err == nil:
+				select {
+				case summaries <- *credits:
+				case <-ctx.Done():
+					return
+				}
+			case !isTransient(err):
+				errs <- err
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return summaries, errs
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestCreditsService_Get(t *testing.T) {
@@ -35,6 +37,13 @@ func TestCreditsService_Get(t *testing.T) {
 			expectedErr:     true,
 			expectedErrType: "*gradium.AuthenticationError",
 		},
+		{
+			name:            "plan expired",
+			responseCode:    http.StatusPaymentRequired,
+			responseBody:    map[string]string{"detail": "Plan expired"},
+			expectedErr:     true,
+			expectedErrType: "*gradium.PaymentRequiredError",
+		},
 		{
 			name:            "rate limited",
 			responseCode:    http.StatusTooManyRequests,
@@ -162,21 +171,128 @@ func TestCreditsService_GetContextCancellation(t *testing.T) {
 	}
 }
 
+func TestCreditsService_Watch(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		switch n {
+		case 2:
+			// Transient failure is skipped
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]string{"detail": "Unavailable"})
+		case 4:
+			w.WriteHeader(http.StatusPaymentRequired)
+			json.NewEncoder(w).Encode(map[string]string{"detail": "Plan expired"})
+		default:
+			json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: int(100 - n)})
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	credits, errs := client.Credits.Watch(ctx, 10*time.Millisecond)
+
+	var remaining []int
+	for summary := range credits {
+		remaining = append(remaining, summary.RemainingCredits)
+	}
+
+	if len(remaining) != 2 || remaining[0] != 99 || remaining[1] != 97 {
+		t.Errorf("expected remaining credits [99 97], got %v", remaining)
+	}
+
+	err := <-errs
+	var paymentErr *PaymentRequiredError
+	if !errors.As(err, &paymentErr) {
+		t.Errorf("expected PaymentRequiredError, got %v", err)
+	}
+
+	if _, ok := <-errs; ok {
+		t.Error("expected error channel to be closed")
+	}
+}
+
+func TestCreditsService_WatchContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: 100})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	credits, errs := client.Credits.Watch(ctx, time.Hour)
+
+	if summary := <-credits; summary.RemainingCredits != 100 {
+		t.Errorf("expected RemainingCredits 100, got %d", summary.RemainingCredits)
+	}
+
+	cancel()
+
+	select {
+	case err, ok := <-errs:
+		if ok {
+			t.Errorf("expected error channel to close without error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("error channel not closed within timeout")
+	}
+	if _, ok := <-credits; ok {
+		t.Error("expected credits channel to be closed")
+	}
+}
+
+func TestCreditsService_WatchInvalidInterval(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: 100})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		credits, errs := client.Credits.Watch(context.Background(), interval)
+		if err := <-errs; !errors.Is(err, ErrValidation) {
+			t.Errorf("interval %v: expected ValidationError, got %v", interval, err)
+		}
+		if _, ok := <-credits; ok {
+			t.Errorf("interval %v: expected credits channel to be closed", interval)
+		}
+		if _, ok := <-errs; ok {
+			t.Errorf("interval %v: expected error channel to be closed", interval)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
 // Helper function to get error type name
 func getErrorTypeName(err error) string {
 	var validationErr *ValidationError
 	var authErr *AuthenticationError
+	var paymentErr *PaymentRequiredError
 	var notFoundErr *NotFoundError
 	var rateLimitErr *RateLimitError
 	var internalErr *InternalServerError
 	var apiErr *APIError
 	var connErr *ConnectionError
+	var baseErr *Error
 
 	switch {
 	case errors.As(err, &validationErr):
 		return "*gradium.ValidationError"
 	case errors.As(err, &authErr):
 		return "*gradium.AuthenticationError"
+	case errors.As(err, &paymentErr):
+		return "*gradium.PaymentRequiredError"
 	case errors.As(err, &notFoundErr):
 		return "*gradium.NotFoundError"
 	case errors.As(err, &rateLimitErr):
@@ -187,7 +303,90 @@ func getErrorTypeName(err error) string {
 		return "*gradium.APIError"
 	case errors.As(err, &connErr):
 		return "*gradium.ConnectionError"
+	case errors.As(err, &baseErr):
+		return "*gradium.Error"
 	default:
 		return ""
 	}
 }
+
+func TestCreditsService_EstimateCost(t *testing.T) {
+	params := TTSParams{VoiceID: "voice-123", OutputFormat: FormatWAV, Text: "Héllo, world!"}
+
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		want       CostEstimate
+		wantErrStr string
+	}{
+		{
+			name:   "server estimate",
+			status: http.StatusOK,
+			body:   `{"credits": 7, "text_length": 13}`,
+			want:   CostEstimate{Credits: 7, TextLength: 13},
+		},
+		{
+			name:   "no endpoint falls back to local estimate",
+			status: http.StatusNotFound,
+			body:   `{"detail": "Not Found"}`,
+			want:   CostEstimate{Credits: 13, TextLength: 13},
+		},
+		{
+			name:       "unauthorized",
+			status:     http.StatusUnauthorized,
+			body:       `{"detail": "Invalid API key"}`,
+			wantErrStr: "*gradium.AuthenticationError",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/usages/estimate" {
+					t.Errorf("expected path /usages/estimate, got %s", r.URL.Path)
+				}
+				q := r.URL.Query()
+				if q.Get("chars") != "13" || q.Get("format") != "wav" || q.Get("model") != DefaultModelName {
+					t.Errorf("unexpected query %v", q)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			estimate, err := client.Credits.EstimateCost(context.Background(), params)
+
+			if tt.wantErrStr != "" {
+				if got := getErrorTypeName(err); got != tt.wantErrStr {
+					t.Errorf("expected %s, got %v", tt.wantErrStr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *estimate != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *estimate)
+			}
+		})
+	}
+}
+
+func TestEstimateTTSCreditsLocal(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"Hello", 5},
+		{"Zoë", 3},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateTTSCreditsLocal(TTSParams{Text: tt.text}); got != tt.want {
+			t.Errorf("EstimateTTSCreditsLocal(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
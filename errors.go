@@ -2,10 +2,38 @@ package gradium
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strconv"
+	"time"
+)
+
+// Sentinel errors matched by the SDK error types through errors.Is, so
+// callers can check the kind of an error without importing concrete types:
+//
+//	if errors.Is(err, gradium.ErrRateLimit) {
+//	    // back off and retry
+//	}
+var (
+	ErrAuthentication  = errors.New("authentication error")
+	ErrValidation      = errors.New("validation error")
+	ErrAPI             = errors.New("API error")
+	ErrNotFound        = errors.New("not found")
+	ErrRateLimit       = errors.New("rate limit exceeded")
+	ErrPaymentRequired = errors.New("payment required")
+	ErrInternalServer  = errors.New("internal server error")
+	ErrWebSocket       = errors.New("websocket error")
+	ErrEmptyResponse   = errors.New("empty response")
+	ErrTimeout         = errors.New("timeout")
+	ErrConnection      = errors.New("connection error")
+
+	// ErrRateLimited is returned without contacting the server when a
+	// client configured with WithRateLimitNonBlocking has no request slot
+	// free.
+	ErrRateLimited = errors.New("client-side rate limit reached")
 )
 
 // Error is the base error type for all SDK errors.
@@ -20,6 +48,9 @@ func (e *Error) Error() string {
 // AuthenticationError is returned when the API key is missing or invalid.
 type AuthenticationError struct {
 	Message string
+	// Err is the underlying error, such as one returned by the
+	// WithAPIKeyFunc function, or nil.
+	Err error
 }
 
 func (e *AuthenticationError) Error() string {
@@ -29,6 +60,16 @@ func (e *AuthenticationError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the underlying error.
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrAuthentication.
+func (e *AuthenticationError) Is(target error) bool {
+	return target == ErrAuthentication
+}
+
 // ValidationErrorDetail contains details about a validation error.
 type ValidationErrorDetail struct {
 	Loc  []interface{} `json:"loc"`
@@ -56,6 +97,11 @@ func (e *ValidationError) Error() string {
 	return msg
 }
 
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 // APIError is returned for general API errors.
 type APIError struct {
 	Status  int
@@ -67,6 +113,11 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("API error (%d): %s", e.Status, e.Message)
 }
 
+// Is reports whether target is ErrAPI.
+func (e *APIError) Is(target error) bool {
+	return target == ErrAPI
+}
+
 // NotFoundError is returned when a resource is not found.
 type NotFoundError struct {
 	Message string
@@ -79,6 +130,11 @@ func (e *NotFoundError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
 // RateLimitError is returned when the rate limit is exceeded.
 type RateLimitError struct {
 	Message    string
@@ -92,6 +148,30 @@ func (e *RateLimitError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is ErrRateLimit.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimit
+}
+
+// PaymentRequiredError is returned when the API responds with 402 Payment
+// Required, typically because the plan has expired or credits are exhausted.
+type PaymentRequiredError struct {
+	Message       string
+	PlanExpiredAt *time.Time
+}
+
+func (e *PaymentRequiredError) Error() string {
+	if e.Message == "" {
+		return "payment required"
+	}
+	return e.Message
+}
+
+// Is reports whether target is ErrPaymentRequired.
+func (e *PaymentRequiredError) Is(target error) bool {
+	return target == ErrPaymentRequired
+}
+
 // InternalServerError is returned for 5xx errors.
 type InternalServerError struct {
 	Status  int
@@ -105,6 +185,11 @@ func (e *InternalServerError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is ErrInternalServer.
+func (e *InternalServerError) Is(target error) bool {
+	return target == ErrInternalServer
+}
+
 // WebSocketError is returned when a WebSocket operation fails.
 type WebSocketError struct {
 	Message string
@@ -118,6 +203,29 @@ func (e *WebSocketError) Error() string {
 	return fmt.Sprintf("websocket error: %s", e.Message)
 }
 
+// Is reports whether target is ErrWebSocket.
+func (e *WebSocketError) Is(target error) bool {
+	return target == ErrWebSocket
+}
+
+// EmptyResponseError is returned when the server ends a stream successfully
+// but without sending any data.
+type EmptyResponseError struct {
+	Message string
+}
+
+func (e *EmptyResponseError) Error() string {
+	if e.Message == "" {
+		return "empty response from the API"
+	}
+	return e.Message
+}
+
+// Is reports whether target is ErrEmptyResponse.
+func (e *EmptyResponseError) Is(target error) bool {
+	return target == ErrEmptyResponse
+}
+
 // TimeoutError is returned when a request times out.
 type TimeoutError struct {
 	Message string
@@ -130,6 +238,29 @@ func (e *TimeoutError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is ErrTimeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// AudioValidationError is returned by STTStream.SendAudio, when
+// STTParams.ValidateAudio is set, for audio that does not match what the
+// stream expects. Expected and Got are the expected and actual byte counts.
+type AudioValidationError struct {
+	Reason   string
+	Expected int
+	Got      int
+}
+
+func (e *AudioValidationError) Error() string {
+	return fmt.Sprintf("invalid audio: %s (expected %d bytes, got %d)", e.Reason, e.Expected, e.Got)
+}
+
+// Is reports whether target is ErrValidation.
+func (e *AudioValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 // ConnectionError is returned when a connection fails.
 type ConnectionError struct {
 	Message string
@@ -142,6 +273,38 @@ func (e *ConnectionError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is ErrConnection.
+func (e *ConnectionError) Is(target error) bool {
+	return target == ErrConnection
+}
+
+// requestError wraps an error returned by http.Client.Do as a TimeoutError
+// if the request timed out, or as a ConnectionError otherwise. ErrRateLimited
+// from the client's own rate limiter is returned unwrapped.
+func requestError(err error) error {
+	if errors.Is(err, ErrRateLimited) {
+		return ErrRateLimited
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{Message: err.Error()}
+	}
+	return &ConnectionError{Message: err.Error()}
+}
+
+// isTransient reports whether err is a temporary failure that may succeed if
+// the request is repeated.
+func isTransient(err error) bool {
+	var rateLimitErr *RateLimitError
+	var internalErr *InternalServerError
+	var connErr *ConnectionError
+	var timeoutErr *TimeoutError
+	return errors.As(err, &rateLimitErr) ||
+		errors.As(err, &internalErr) ||
+		errors.As(err, &connErr) ||
+		errors.As(err, &timeoutErr)
+}
+
 // httpValidationError is the JSON structure for 422 errors.
 type httpValidationError struct {
 	Detail []ValidationErrorDetail `json:"detail"`
@@ -174,6 +337,13 @@ func handleAPIError(resp *http.Response) error {
 	case 401, 403:
 		return &AuthenticationError{Message: getMessage()}
 
+	case 402:
+		var payment struct {
+			PlanExpiredAt *time.Time `json:"plan_expired_at"`
+		}
+		_ = json.Unmarshal(body, &payment)
+		return &PaymentRequiredError{Message: getMessage(), PlanExpiredAt: payment.PlanExpiredAt}
+
 	case 404:
 		return &NotFoundError{Message: getMessage()}
 
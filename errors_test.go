@@ -2,10 +2,12 @@ package gradium
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestError(t *testing.T) {
@@ -148,6 +150,34 @@ func TestRateLimitError(t *testing.T) {
 	}
 }
 
+func TestPaymentRequiredError(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "with message",
+			message:  "plan expired",
+			expected: "plan expired",
+		},
+		{
+			name:     "without message",
+			message:  "",
+			expected: "payment required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &PaymentRequiredError{Message: tt.message}
+			if err.Error() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
 func TestInternalServerError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -299,6 +329,12 @@ func TestHandleAPIError(t *testing.T) {
 			body:       `{"detail": "Access denied"}`,
 			errType:    "*gradium.AuthenticationError",
 		},
+		{
+			name:       "402 payment required error",
+			statusCode: 402,
+			body:       `{"detail": "Plan expired"}`,
+			errType:    "*gradium.PaymentRequiredError",
+		},
 		{
 			name:       "404 not found error",
 			statusCode: 404,
@@ -351,6 +387,7 @@ func TestHandleAPIError(t *testing.T) {
 			// Check error type using errors.As
 			var validationErr *ValidationError
 			var authErr *AuthenticationError
+			var paymentErr *PaymentRequiredError
 			var notFoundErr *NotFoundError
 			var rateLimitErr *RateLimitError
 			var internalErr *InternalServerError
@@ -362,6 +399,8 @@ func TestHandleAPIError(t *testing.T) {
 				errTypeName = "*gradium.ValidationError"
 			case errors.As(err, &authErr):
 				errTypeName = "*gradium.AuthenticationError"
+			case errors.As(err, &paymentErr):
+				errTypeName = "*gradium.PaymentRequiredError"
 			case errors.As(err, &notFoundErr):
 				errTypeName = "*gradium.NotFoundError"
 			case errors.As(err, &rateLimitErr):
@@ -387,7 +426,11 @@ func TestHandleAPIErrorRetryAfter(t *testing.T) {
 	}
 	resp.Header.Set("Retry-After", "120")
 
-	err := handleAPIError(resp)
+	// The kind check and the field access work on the same wrapped error.
+	err := fmt.Errorf("listing voices: %w", handleAPIError(resp))
+	if !errors.Is(err, ErrRateLimit) {
+		t.Errorf("expected %v to match ErrRateLimit", err)
+	}
 	var rateLimitErr *RateLimitError
 	if !errors.As(err, &rateLimitErr) {
 		t.Fatalf("expected RateLimitError, got %T", err)
@@ -397,6 +440,30 @@ func TestHandleAPIErrorRetryAfter(t *testing.T) {
 	}
 }
 
+func TestHandleAPIErrorPlanExpiredAt(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 402,
+		Body:       &mockReadCloser{Reader: strings.NewReader(`{"detail": "Plan expired", "plan_expired_at": "2024-01-31T23:59:59Z"}`)},
+		Header:     make(http.Header),
+	}
+
+	err := handleAPIError(resp)
+	var paymentErr *PaymentRequiredError
+	if !errors.As(err, &paymentErr) {
+		t.Fatalf("expected PaymentRequiredError, got %T", err)
+	}
+	if paymentErr.Message != "Plan expired" {
+		t.Errorf("expected message 'Plan expired', got %q", paymentErr.Message)
+	}
+	if paymentErr.PlanExpiredAt == nil {
+		t.Fatal("expected PlanExpiredAt to be set")
+	}
+	expected := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+	if !paymentErr.PlanExpiredAt.Equal(expected) {
+		t.Errorf("expected PlanExpiredAt %v, got %v", expected, *paymentErr.PlanExpiredAt)
+	}
+}
+
 func TestValidationErrorDetail(t *testing.T) {
 	detail := ValidationErrorDetail{
 		Loc:  []interface{}{"body", "voice_id"},
@@ -416,15 +483,53 @@ func TestValidationErrorDetail(t *testing.T) {
 }
 
 // Test that all error types implement the error interface
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		err      error
+		sentinel error
+	}{
+		{&AuthenticationError{}, ErrAuthentication},
+		{&ValidationError{}, ErrValidation},
+		{&APIError{}, ErrAPI},
+		{&NotFoundError{}, ErrNotFound},
+		{&RateLimitError{}, ErrRateLimit},
+		{&PaymentRequiredError{}, ErrPaymentRequired},
+		{&InternalServerError{}, ErrInternalServer},
+		{&WebSocketError{}, ErrWebSocket},
+		{&EmptyResponseError{}, ErrEmptyResponse},
+		{&TimeoutError{}, ErrTimeout},
+		{&ConnectionError{}, ErrConnection},
+		{&AudioValidationError{}, ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentinel.Error(), func(t *testing.T) {
+			if !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("expected %T to match %v", tt.err, tt.sentinel)
+			}
+			if !errors.Is(fmt.Errorf("wrapped: %w", tt.err), tt.sentinel) {
+				t.Errorf("expected wrapped %T to match %v", tt.err, tt.sentinel)
+			}
+			for _, other := range tests {
+				if other.sentinel != tt.sentinel && errors.Is(tt.err, other.sentinel) {
+					t.Errorf("expected %T not to match %v", tt.err, other.sentinel)
+				}
+			}
+		})
+	}
+}
+
 func TestErrorInterface(_ *testing.T) {
 	var _ error = &Error{}
 	var _ error = &AuthenticationError{}
 	var _ error = &ValidationError{}
 	var _ error = &APIError{}
+	var _ error = &PaymentRequiredError{}
 	var _ error = &NotFoundError{}
 	var _ error = &RateLimitError{}
 	var _ error = &InternalServerError{}
 	var _ error = &WebSocketError{}
+	var _ error = &EmptyResponseError{}
 	var _ error = &TimeoutError{}
 	var _ error = &ConnectionError{}
 }
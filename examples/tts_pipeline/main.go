@@ -0,0 +1,84 @@
+// Example: Pipelined Text-to-Speech with Gradium SDK
+//
+// Text is streamed in word by word, as it would be from an LLM, and audio is
+// received at the same time, so playback can start before the reply is
+// complete.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	gradium "github.com/confiture-ai/gradium-sdk-go"
+)
+
+func main() {
+	client, err := gradium.NewClient() // uses GRADIUM_API_KEY env var
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Ctrl+C stops both sending and receiving and closes the stream.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.TTS.Stream(ctx, gradium.TTSParams{
+		VoiceID:      "YTpq7expH9539ERJ",
+		OutputFormat: gradium.FormatPCM,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	// Simulate a language model producing its reply one word at a time.
+	reply := "Sure! Pipelining lets the first words play while the rest of the answer is still being written."
+	textCh := make(chan string)
+	go func() {
+		defer close(textCh)
+		for i, word := range strings.Fields(reply) {
+			if i > 0 {
+				word = " " + word
+			}
+			select {
+			case textCh <- word:
+			case <-ctx.Done():
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	audio, err := stream.Pipeline(ctx, textCh)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := os.Create("pipeline.pcm")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = out.Close() }()
+
+	start := time.Now()
+	var totalBytes int
+	for chunk := range audio {
+		if totalBytes == 0 {
+			fmt.Printf("First audio after %v\n", time.Since(start).Round(time.Millisecond))
+		}
+		totalBytes += len(chunk)
+		if _, err := out.Write(chunk); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := <-stream.DoneErr(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Stream complete. %d bytes saved to pipeline.pcm\n", totalBytes)
+}
@@ -2,4 +2,7 @@ module github.com/confiture-ai/gradium-sdk-go
 
 go 1.25
 
-require github.com/gorilla/websocket v1.5.3
+require (
+	github.com/gorilla/websocket v1.5.3
+	golang.org/x/time v0.14.0
+)
@@ -1,10 +1,18 @@
 package gradium
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 // Region represents the API region.
@@ -56,7 +64,9 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
-// WithTimeout sets the HTTP request timeout.
+// WithTimeout sets the HTTP request timeout. It covers the whole request,
+// including uploading the body, so raise it for large voice uploads.
+// Requests that exceed it fail with a TimeoutError.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
 		c.timeout = timeout
@@ -64,6 +74,62 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithTTSTimeout bounds each TTSService.Create call, including each input of
+// CreateBatch, to timeout when the caller's context has no deadline of its
+// own. Streams opened with Stream are long-lived and are not bounded.
+func WithTTSTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.ttsTimeout = timeout
+	}
+}
+
+// WithSTTTimeout bounds each STTService.Transcribe, TranscribeDetailed and
+// VADOnly call to timeout when the caller's context has no deadline of its
+// own. Streams opened with Stream are long-lived and are not bounded.
+func WithSTTTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.sttTimeout = timeout
+	}
+}
+
+// WithDefaultTTSModel sets the model used by TTS requests whose
+// TTSParams.ModelName is empty, in place of DefaultModelName. An explicit
+// ModelName still takes precedence.
+func WithDefaultTTSModel(model string) ClientOption {
+	return func(c *Client) {
+		c.ttsModel = model
+	}
+}
+
+// WithDefaultSTTModel sets the model used by STT requests whose
+// STTParams.ModelName is empty, in place of DefaultModelName. An explicit
+// ModelName still takes precedence.
+func WithDefaultSTTModel(model string) ClientOption {
+	return func(c *Client) {
+		c.sttModel = model
+	}
+}
+
+// WithVoicesTimeout bounds each VoicesService call to timeout, retries
+// included, when the caller's context has no deadline of its own. Each HTTP
+// request is still subject to the client-wide WithTimeout, so raise that too
+// if the voices timeout is longer.
+func WithVoicesTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.voicesTimeout = timeout
+	}
+}
+
+// WithVoicesBatchConcurrency sets how many requests VoicesService.DeleteBatch
+// and UpdateBatch run at a time. Values below 1 are ignored. The default is 5.
+func WithVoicesBatchConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.voicesBatchConcurrency = n
+		}
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
@@ -71,6 +137,137 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithWebSocketDialer sets the dialer used to open TTS and STT streams,
+// instead of websocket.DefaultDialer. Use it to route streams through a
+// proxy with NetDial or Proxy, trust custom CAs with TLSClientConfig, or
+// change HandshakeTimeout. If the dialer has its own TLSClientConfig, it
+// takes precedence over WithTLSConfig for WebSocket connections.
+//
+// Example:
+//
+//	socks, _ := proxy.SOCKS5("tcp", "proxy:1080", nil, proxy.Direct)
+//	client, err := gradium.NewClient(gradium.WithWebSocketDialer(&websocket.Dialer{
+//	    NetDial:          socks.Dial,
+//	    TLSClientConfig:  &tls.Config{RootCAs: pool},
+//	    HandshakeTimeout: 10 * time.Second,
+//	}))
+func WithWebSocketDialer(dialer *websocket.Dialer) ClientOption {
+	return func(c *Client) {
+		c.wsDialer = dialer
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of both HTTP requests and
+// WebSocket streams, for example to trust a corporate CA or to present a
+// client certificate. HTTP requests use a clone of the HTTP client's
+// *http.Transport (or of http.DefaultTransport) with TLSClientConfig set to
+// tlsCfg; a custom RoundTripper of another type is left as is. Streams use
+// tlsCfg unless WithWebSocketDialer supplies a dialer with its own
+// TLSClientConfig.
+//
+// Example:
+//
+//	pool, _ := x509.SystemCertPool()
+//	pool.AppendCertsFromPEM(corporateCA)
+//	client, err := gradium.NewClient(gradium.WithTLSConfig(&tls.Config{RootCAs: pool}))
+func WithTLSConfig(tlsCfg *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = tlsCfg
+	}
+}
+
+// WithWebSocketBufferSizes sets the read and write buffer sizes, in bytes, of
+// TTS and STT stream connections. Larger buffers mean fewer system calls when
+// receiving large audio frames, such as WAV output. Values below 1 keep
+// gorilla/websocket's default of 4096. It applies on top of
+// WithWebSocketDialer, whose dialer is copied rather than modified.
+//
+// Example:
+//
+//	client, err := gradium.NewClient(gradium.WithWebSocketBufferSizes(65536, 4096))
+func WithWebSocketBufferSizes(readBuf, writeBuf int) ClientOption {
+	return func(c *Client) {
+		c.wsReadBufferSize = readBuf
+		c.wsWriteBufferSize = writeBuf
+	}
+}
+
+// WithTTSTextSplitter sets the splitter used by TTSService.Create to break
+// long texts into chunks of at most maxChunkLen bytes, each sent as a separate
+// text message. A maxChunkLen of 0 keeps the default limit of 4096 bytes; a
+// negative maxChunkLen disables the limit.
+func WithTTSTextSplitter(splitter TTSTextSplitter, maxChunkLen int) ClientOption {
+	return func(c *Client) {
+		c.textSplitter = splitter
+		if maxChunkLen != 0 {
+			c.maxTextChunkLen = maxChunkLen
+		}
+	}
+}
+
+// WithTTSAudioChannelSize sets the capacity of the audio channel returned by
+// TTSStream.Audio. Smaller buffers reduce playback latency; larger ones suit
+// batch processing. Values below 1 are ignored. The default is 100.
+func WithTTSAudioChannelSize(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.ttsAudioChannelSize = n
+		}
+	}
+}
+
+// WithSTTChannelSizes sets the capacities of the channels returned by
+// STTStream.Text, VAD, EndText and All. VAD fires frequently and may need a
+// larger buffer, while EndText fires once per utterance. Values below 1 keep
+// the default for that channel (100, 100, 10 and 100 respectively).
+func WithSTTChannelSizes(text, vad, endText, all int) ClientOption {
+	return func(c *Client) {
+		if text > 0 {
+			c.sttChannelSizes.text = text
+		}
+		if vad > 0 {
+			c.sttChannelSizes.vad = vad
+		}
+		if endText > 0 {
+			c.sttChannelSizes.endText = endText
+		}
+		if all > 0 {
+			c.sttChannelSizes.all = all
+		}
+	}
+}
+
+// WithChannelBufferSize sets the capacity of every stream channel: the TTS
+// Audio channel and the STT Text, VAD, EndText and All channels. Messages
+// that arrive while a channel is full are dropped and counted by the
+// stream's Dropped method. Values below 1 are ignored.
+func WithChannelBufferSize(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.ttsAudioChannelSize = n
+			c.sttChannelSizes = sttChannelSizes{text: n, vad: n, endText: n, all: n}
+		}
+	}
+}
+
+// WithBaseContext sets a shared context whose values (for example a logger
+// or tracing span) are visible to every request made by the client.
+// Values on the caller's context take precedence; deadlines and cancellation
+// still come from the caller's context only.
+func WithBaseContext(ctx context.Context) ClientOption {
+	return func(c *Client) {
+		c.baseCtx = ctx
+	}
+}
+
+// sttChannelSizes holds the buffer capacities of STTStream's channels.
+type sttChannelSizes struct {
+	text    int
+	vad     int
+	endText int
+	all     int
+}
+
 // Client is the Gradium API client.
 type Client struct {
 	apiKey     string
@@ -79,6 +276,38 @@ type Client struct {
 	wsURL      string
 	timeout    time.Duration
 	httpClient *http.Client
+	wsDialer   *websocket.Dialer
+
+	wsReadBufferSize  int
+	wsWriteBufferSize int
+	tlsConfig         *tls.Config
+
+	apiKeyFunc  func(context.Context) (string, error)
+	apiKeyTTL   time.Duration
+	apiKeyCache *apiKeyCache // set when apiKeyFunc is; replaces apiKey
+
+	ttsTimeout    time.Duration
+	sttTimeout    time.Duration
+	voicesTimeout time.Duration
+
+	ttsModel string
+	sttModel string
+
+	voicesBatchConcurrency int
+
+	textSplitter    TTSTextSplitter
+	maxTextChunkLen int
+
+	ttsAudioChannelSize int
+	sttChannelSizes     sttChannelSizes
+
+	baseCtx       context.Context
+	requestLogger RequestLogger
+	logger        *slog.Logger
+	middleware    []MiddlewareFunc
+	streamTracer  StreamTracer
+	retryPolicy   *RetryPolicy
+	rateLimiter   *rateLimiter
 
 	// Resources
 	TTS     *TTSService
@@ -90,38 +319,292 @@ type Client struct {
 // NewClient creates a new Gradium client.
 func NewClient(opts ...ClientOption) (*Client, error) {
 	c := &Client{
-		region:  RegionEU,
-		baseURL: apiURLs[RegionEU],
-		wsURL:   wsURLs[RegionEU],
-		timeout: 30 * time.Second,
+		region:    RegionEU,
+		baseURL:   apiURLs[RegionEU],
+		wsURL:     wsURLs[RegionEU],
+		timeout:   30 * time.Second,
+		apiKeyTTL: defaultAPIKeyTTL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		textSplitter:           SentenceSplitter{},
+		maxTextChunkLen:        defaultMaxTextChunkLen,
+		voicesBatchConcurrency: defaultVoicesBatchConcurrency,
+		ttsAudioChannelSize:    100,
+		sttChannelSizes: sttChannelSizes{
+			text:    100,
+			vad:     100,
+			endText: 10,
+			all:     100,
+		},
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.tlsConfig != nil {
+		c.applyTLSConfig()
+	}
+
+	if c.wsReadBufferSize > 0 || c.wsWriteBufferSize > 0 {
+		dialer := *c.dialer()
+		if c.wsReadBufferSize > 0 {
+			dialer.ReadBufferSize = c.wsReadBufferSize
+		}
+		if c.wsWriteBufferSize > 0 {
+			dialer.WriteBufferSize = c.wsWriteBufferSize
+		}
+		c.wsDialer = &dialer
+	}
+
+	if c.apiKeyFunc != nil {
+		c.apiKeyCache = &apiKeyCache{fetch: c.apiKeyFunc, ttl: c.apiKeyTTL}
+	}
+
 	// If no API key was set via options, read from environment
-	if c.apiKey == "" {
+	if c.apiKey == "" && c.apiKeyCache == nil {
 		c.apiKey = os.Getenv("GRADIUM_API_KEY")
 	}
-	if c.apiKey == "" {
+	if c.apiKey == "" && c.apiKeyCache == nil {
 		return nil, &AuthenticationError{Message: "API key is required. Use WithAPIKey option or set GRADIUM_API_KEY environment variable."}
 	}
 
-	// Initialize services
-	c.TTS = &TTSService{client: c}
-	c.STT = &STTService{client: c}
-	c.Voices = &VoicesService{client: c}
-	c.Credits = &CreditsService{client: c}
+	if c.rateLimiter != nil {
+		c.wrapTransport(func(next http.RoundTripper) http.RoundTripper {
+			return &rateLimitTransport{limiter: c.rateLimiter, next: next}
+		})
+	}
+
+	if c.requestLogger != nil {
+		c.wrapTransport(func(next http.RoundTripper) http.RoundTripper {
+			return &loggingTransport{next: next, logger: c.requestLogger}
+		})
+	}
+	if c.logger != nil {
+		c.wrapTransport(func(next http.RoundTripper) http.RoundTripper {
+			return &loggingTransport{next: next, logger: slogRequestLogger{logger: c.logger}}
+		})
+	}
+
+	// Wrap in reverse so the first middleware is outermost. The logger sits
+	// beneath the chain and sees any headers the middleware added.
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		fn := c.middleware[i]
+		c.wrapTransport(func(next http.RoundTripper) http.RoundTripper {
+			return &middlewareTransport{fn: fn, next: next}
+		})
+	}
+
+	c.initServices()
 
 	return c, nil
 }
 
-// APIKey returns the API key.
+// applyTLSConfig installs c.tlsConfig on the HTTP transport and, unless the
+// dialer has TLS settings of its own, on the WebSocket dialer. Both are
+// copied so caller-supplied values are left unmodified.
+func (c *Client) applyTLSConfig() {
+	var transport *http.Transport
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	}
+	if transport != nil {
+		transport.TLSClientConfig = c.tlsConfig
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+
+	if c.dialer().TLSClientConfig == nil {
+		dialer := *c.dialer()
+		dialer.TLSClientConfig = c.tlsConfig
+		c.wsDialer = &dialer
+	}
+}
+
+// wrapTransport installs a RoundTripper around the HTTP client's transport.
+// The HTTP client is copied so a caller-supplied one is left unmodified.
+func (c *Client) wrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	httpClient := *c.httpClient
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = wrap(next)
+	c.httpClient = &httpClient
+}
+
+// initServices points the resource services at c.
+func (c *Client) initServices() {
+	c.TTS = &TTSService{client: c, timeout: c.ttsTimeout}
+	c.STT = &STTService{client: c, timeout: c.sttTimeout}
+	c.Voices = &VoicesService{client: c, timeout: c.voicesTimeout, batchConcurrency: c.voicesBatchConcurrency}
+	c.Credits = &CreditsService{client: c}
+}
+
+// WithAPIKey returns a shallow copy of the client that authenticates with
+// apiKey. The copy shares the HTTP client and all other configuration with c,
+// which is left unmodified. This is useful in multi-tenant setups where each
+// request carries its own key. The copy does not use a WithAPIKeyFunc
+// function.
+func (c *Client) WithAPIKey(apiKey string) *Client {
+	clone := *c
+	clone.apiKey = apiKey
+	clone.apiKeyFunc = nil
+	clone.apiKeyCache = nil
+	clone.initServices()
+	return &clone
+}
+
+// resolveModelName returns name, or else the client default, or else
+// DefaultModelName.
+func resolveModelName(name, clientDefault string) string {
+	switch {
+	case name != "":
+		return name
+	case clientDefault != "":
+		return clientDefault
+	default:
+		return DefaultModelName
+	}
+}
+
+// withDefaultTimeout bounds ctx by timeout unless timeout is zero or ctx
+// already has a deadline, which takes priority.
+func withDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// requestContext returns the context to use for an outgoing request,
+// layering the base context's values under ctx.
+func (c *Client) requestContext(ctx context.Context) context.Context {
+	if c.baseCtx == nil {
+		return ctx
+	}
+	return &baseValueContext{Context: ctx, base: c.baseCtx}
+}
+
+// baseValueContext is a context that falls back to base for values not
+// found in the embedded context.
+type baseValueContext struct {
+	context.Context
+	base context.Context
+}
+
+func (c *baseValueContext) Value(key interface{}) interface{} {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.base.Value(key)
+}
+
+// dialWebSocket opens an authenticated WebSocket connection to path under
+// the client's WebSocket URL, retrying according to the retry policy.
+// service names the endpoint in handshake errors.
+func (c *Client) dialWebSocket(ctx context.Context, service, path string) (*websocket.Conn, *http.Response, error) {
+	header := http.Header{}
+	if err := c.setAPIKey(ctx, header); err != nil {
+		return nil, nil, err
+	}
+
+	var resp *http.Response
+	conn, err := retry(ctx, c, func() (*websocket.Conn, error) {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		conn, r, err := c.dialer().DialContext(c.requestContext(ctx), c.wsURL+path, header)
+		resp = r
+		c.rateLimiter.observe(r)
+		if err != nil {
+			if ctxErr := dialContextError(ctx, err); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if r != nil {
+				c.log().Debug(logEventHandshake, logKeyService, service,
+					"status", r.StatusCode, "headers", redactHeaders(r.Header))
+			}
+			return nil, handshakeError(service, r, err)
+		}
+		c.log().Info(logEventConnected, logKeyService, service, "url", c.wsURL+path)
+		return conn, nil
+	})
+	return conn, resp, err
+}
+
+// dialer returns the client's WebSocket dialer, or websocket.DefaultDialer.
+func (c *Client) dialer() *websocket.Dialer {
+	if c.wsDialer == nil {
+		return websocket.DefaultDialer
+	}
+	return c.wsDialer
+}
+
+// Services named in WebSocket errors and logs.
+const (
+	serviceTTS = "TTS"
+	serviceSTT = "STT"
+)
+
+// trySend sends v on ch without blocking. If ch is full it counts v in
+// dropped and returns false.
+func trySend[T any](ch chan T, v T, dropped *atomic.Int64) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		dropped.Add(1)
+		return false
+	}
+}
+
+// gunzipBody replaces resp.Body with a decompressing reader when the
+// response is gzip-encoded. Setting Accept-Encoding explicitly turns off the
+// transparent decompression of http.Transport, so the SDK decodes gzip
+// itself; this also covers custom transports that never decompress.
+func gunzipBody(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return nil
+}
+
+// gzipBody reads a decompressed response body and closes both the gzip
+// reader and the underlying body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (b *gzipBody) Close() error {
+	_ = b.Reader.Close()
+	return b.body.Close()
+}
+
+// APIKey returns the API key. With WithAPIKeyFunc it returns the key last
+// fetched, or an empty string before the first request.
 func (c *Client) APIKey() string {
+	if c.apiKeyCache != nil {
+		return c.apiKeyCache.cached()
+	}
 	return c.apiKey
 }
 
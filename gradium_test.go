@@ -1,10 +1,22 @@
 package gradium
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 func TestNewClient(t *testing.T) {
@@ -218,6 +230,85 @@ func TestWithHTTPClient(t *testing.T) {
 	}
 }
 
+func TestWithTTSAudioChannelSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		expected int
+	}{
+		{name: "default", opts: nil, expected: 100},
+		{name: "custom", opts: []ClientOption{WithTTSAudioChannelSize(8)}, expected: 8},
+		{name: "invalid ignored", opts: []ClientOption{WithTTSAudioChannelSize(0)}, expected: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(append([]ClientOption{WithAPIKey("test-key")}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.ttsAudioChannelSize != tt.expected {
+				t.Errorf("expected channel size %d, got %d", tt.expected, client.ttsAudioChannelSize)
+			}
+		})
+	}
+}
+
+func TestWithSTTChannelSizes(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"), WithSTTChannelSizes(10, 500, 0, 1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := sttChannelSizes{text: 10, vad: 500, endText: 10, all: 1000}
+	if client.sttChannelSizes != expected {
+		t.Errorf("expected channel sizes %+v, got %+v", expected, client.sttChannelSizes)
+	}
+}
+
+func TestWithChannelBufferSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantTTS int
+		wantSTT sttChannelSizes
+	}{
+		{
+			name:    "all channels",
+			opts:    []ClientOption{WithChannelBufferSize(1000)},
+			wantTTS: 1000,
+			wantSTT: sttChannelSizes{text: 1000, vad: 1000, endText: 1000, all: 1000},
+		},
+		{
+			name:    "invalid ignored",
+			opts:    []ClientOption{WithChannelBufferSize(-1)},
+			wantTTS: 100,
+			wantSTT: sttChannelSizes{text: 100, vad: 100, endText: 10, all: 100},
+		},
+		{
+			name:    "later specific option wins",
+			opts:    []ClientOption{WithChannelBufferSize(1000), WithSTTChannelSizes(0, 5000, 0, 0)},
+			wantTTS: 1000,
+			wantSTT: sttChannelSizes{text: 1000, vad: 5000, endText: 1000, all: 1000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(append([]ClientOption{WithAPIKey("test-key")}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.ttsAudioChannelSize != tt.wantTTS {
+				t.Errorf("expected TTS channel size %d, got %d", tt.wantTTS, client.ttsAudioChannelSize)
+			}
+			if client.sttChannelSizes != tt.wantSTT {
+				t.Errorf("expected STT channel sizes %+v, got %+v", tt.wantSTT, client.sttChannelSizes)
+			}
+		})
+	}
+}
+
 func TestMultipleOptions(t *testing.T) {
 	timeout := 45 * time.Second
 	client, err := NewClient(
@@ -237,6 +328,187 @@ func TestMultipleOptions(t *testing.T) {
 	}
 }
 
+func TestClientWithAPIKey(t *testing.T) {
+	var receivedKey string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedKey = r.Header.Get("x-api-key")
+		json.NewEncoder(w).Encode(CreditsSummary{})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("original-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tenant := client.WithAPIKey("tenant-key")
+
+	if tenant == client {
+		t.Fatal("expected a new client")
+	}
+	if tenant.APIKey() != "tenant-key" {
+		t.Errorf("expected API key 'tenant-key', got %q", tenant.APIKey())
+	}
+	if client.APIKey() != "original-key" {
+		t.Errorf("expected original client key unchanged, got %q", client.APIKey())
+	}
+	if tenant.httpClient != client.httpClient {
+		t.Error("expected HTTP client to be shared")
+	}
+	if tenant.BaseURL() != client.BaseURL() {
+		t.Errorf("expected base URL %q, got %q", client.BaseURL(), tenant.BaseURL())
+	}
+
+	// Services on the copy must use the new key
+	if _, err := tenant.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receivedKey != "tenant-key" {
+		t.Errorf("expected request with 'tenant-key', got %q", receivedKey)
+	}
+
+	if _, err := client.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receivedKey != "original-key" {
+		t.Errorf("expected request with 'original-key', got %q", receivedKey)
+	}
+}
+
+type ctxKey string
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithBaseContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(CreditsSummary{})
+	}))
+	defer server.Close()
+
+	var gotBase, gotCaller interface{}
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			gotBase = req.Context().Value(ctxKey("base"))
+			gotCaller = req.Context().Value(ctxKey("shared"))
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	baseCtx := context.WithValue(context.Background(), ctxKey("base"), "from-base")
+	baseCtx = context.WithValue(baseCtx, ctxKey("shared"), "base-value")
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithBaseContext(baseCtx),
+	)
+
+	ctx := context.WithValue(context.Background(), ctxKey("shared"), "caller-value")
+	if _, err := client.Credits.Get(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotBase != "from-base" {
+		t.Errorf("expected base context value 'from-base', got %v", gotBase)
+	}
+	if gotCaller != "caller-value" {
+		t.Errorf("expected caller value to take precedence, got %v", gotCaller)
+	}
+
+	// Cancellation still comes from the caller's context
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Credits.Get(cancelled); err == nil {
+		t.Error("expected error for cancelled caller context")
+	}
+}
+
+func TestGzipResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   interface{}
+		call   func(c *Client) error
+	}{
+		{
+			name:   "voices list",
+			status: http.StatusOK,
+			body:   []Voice{{UID: "voice-1", Name: "One"}},
+			call: func(c *Client) error {
+				voices, err := c.Voices.List(context.Background(), nil)
+				if err == nil && (len(voices) != 1 || voices[0].UID != "voice-1") {
+					return fmt.Errorf("unexpected voices %+v", voices)
+				}
+				return err
+			},
+		},
+		{
+			name:   "voice get",
+			status: http.StatusOK,
+			body:   Voice{UID: "voice-1", Name: "One"},
+			call: func(c *Client) error {
+				voice, err := c.Voices.Get(context.Background(), "voice-1")
+				if err == nil && voice.Name != "One" {
+					return fmt.Errorf("unexpected voice %+v", voice)
+				}
+				return err
+			},
+		},
+		{
+			name:   "credits get",
+			status: http.StatusOK,
+			body:   CreditsSummary{RemainingCredits: 42},
+			call: func(c *Client) error {
+				credits, err := c.Credits.Get(context.Background())
+				if err == nil && credits.RemainingCredits != 42 {
+					return fmt.Errorf("unexpected credits %+v", credits)
+				}
+				return err
+			},
+		},
+		{
+			name:   "error response",
+			status: http.StatusNotFound,
+			body:   map[string]string{"detail": "Voice not found"},
+			call: func(c *Client) error {
+				_, err := c.Voices.Get(context.Background(), "missing")
+				var notFoundErr *NotFoundError
+				if !errors.As(err, &notFoundErr) || notFoundErr.Message != "Voice not found" {
+					return fmt.Errorf("expected NotFoundError with detail, got %v", err)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != "gzip" {
+					t.Errorf("expected Accept-Encoding 'gzip', got %q", r.Header.Get("Accept-Encoding"))
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				w.WriteHeader(tt.status)
+				zw := gzip.NewWriter(w)
+				_ = json.NewEncoder(zw).Encode(tt.body)
+				_ = zw.Close()
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if err := tt.call(client); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func TestRegionConstants(t *testing.T) {
 	if RegionEU != "eu" {
 		t.Errorf("expected RegionEU to be 'eu', got %q", RegionEU)
@@ -263,3 +535,350 @@ func TestWSURLs(t *testing.T) {
 		t.Error("missing US WebSocket URL")
 	}
 }
+
+func TestWithWebSocketDialer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	var dials atomic.Int32
+	transport := server.Client().Transport.(*http.Transport)
+	dialer := &websocket.Dialer{
+		NetDial: func(network, addr string) (net.Conn, error) {
+			dials.Add(1)
+			return net.Dial(network, addr)
+		},
+		TLSClientConfig: transport.TLSClientConfig,
+	}
+
+	tests := []struct {
+		name      string
+		opts      []ClientOption
+		wantErr   bool
+		wantDials int32
+	}{
+		{name: "default dialer rejects unknown CA", wantErr: true},
+		{name: "custom dialer", opts: []ClientOption{WithWebSocketDialer(dialer)}, wantDials: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dials.Store(0)
+			client, _ := NewClient(append([]ClientOption{WithAPIKey("test-key")}, tt.opts...)...)
+			client.wsURL = "wss" + strings.TrimPrefix(server.URL, "https")
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if tt.wantErr {
+				if err == nil {
+					stream.Close()
+					t.Fatal("expected dial error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			if err := stream.WaitReady(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := dials.Load(); got != tt.wantDials {
+				t.Errorf("expected %d dials through the custom dialer, got %d", tt.wantDials, got)
+			}
+		})
+	}
+}
+
+func TestWithVoicesTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		deadline time.Duration
+		delay    time.Duration
+		wantErr  bool
+	}{
+		{name: "service timeout applies", timeout: 50 * time.Millisecond, delay: time.Second, wantErr: true},
+		{name: "caller deadline takes priority", timeout: 50 * time.Millisecond, deadline: 5 * time.Second, delay: 150 * time.Millisecond},
+		{name: "no service timeout", delay: 150 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				json.NewEncoder(w).Encode(Voice{UID: "voice-123"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithVoicesTimeout(tt.timeout))
+
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			_, err := client.Voices.Get(ctx, "voice-123")
+			if tt.wantErr {
+				if !errors.Is(err, ErrTimeout) {
+					t.Errorf("expected ErrTimeout, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithTTSTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Never report ready
+		conn.ReadMessage()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTTSTimeout(50*time.Millisecond))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	start := time.Now()
+	_, err := client.TTS.Create(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM, Text: "Hello"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("timeout not applied, took %v", elapsed)
+	}
+}
+
+func TestWithWebSocketBufferSizes(t *testing.T) {
+	custom := &websocket.Dialer{HandshakeTimeout: 7 * time.Second}
+
+	tests := []struct {
+		name      string
+		opts      []ClientOption
+		wantRead  int
+		wantWrite int
+		wantSame  *websocket.Dialer
+	}{
+		{name: "default", wantSame: websocket.DefaultDialer},
+		{name: "ignored values", opts: []ClientOption{WithWebSocketBufferSizes(0, -1)}, wantSame: websocket.DefaultDialer},
+		{name: "both sizes", opts: []ClientOption{WithWebSocketBufferSizes(65536, 8192)}, wantRead: 65536, wantWrite: 8192},
+		{name: "read only", opts: []ClientOption{WithWebSocketBufferSizes(65536, 0)}, wantRead: 65536},
+		{
+			name:     "applied to custom dialer",
+			opts:     []ClientOption{WithWebSocketBufferSizes(65536, 0), WithWebSocketDialer(custom)},
+			wantRead: 65536,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := NewClient(append([]ClientOption{WithAPIKey("test-key")}, tt.opts...)...)
+			dialer := client.dialer()
+			if tt.wantSame != nil {
+				if dialer != tt.wantSame {
+					t.Error("expected the dialer to be left unchanged")
+				}
+				return
+			}
+			if dialer == websocket.DefaultDialer || dialer == custom {
+				t.Fatal("expected a copy of the dialer")
+			}
+			if dialer.ReadBufferSize != tt.wantRead || dialer.WriteBufferSize != tt.wantWrite {
+				t.Errorf("expected buffers %d/%d, got %d/%d", tt.wantRead, tt.wantWrite, dialer.ReadBufferSize, dialer.WriteBufferSize)
+			}
+		})
+	}
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithWebSocketDialer(custom), WithWebSocketBufferSizes(65536, 0))
+	if client.dialer().HandshakeTimeout != custom.HandshakeTimeout {
+		t.Error("expected the custom dialer's settings to be kept")
+	}
+	if custom.ReadBufferSize != 0 || websocket.DefaultDialer.ReadBufferSize != 0 {
+		t.Error("expected the original dialers to be left unmodified")
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/usages/credits" {
+			w.Write([]byte(`{"remaining_credits": 1}`))
+			return
+		}
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	trusted := &tls.Config{RootCAs: pool}
+
+	var dials atomic.Int32
+	plainDialer := &websocket.Dialer{NetDial: func(network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		return net.Dial(network, addr)
+	}}
+	untrustingDialer := &websocket.Dialer{TLSClientConfig: &tls.Config{RootCAs: x509.NewCertPool()}}
+
+	tests := []struct {
+		name      string
+		opts      []ClientOption
+		wantHTTP  bool
+		wantWS    bool
+		wantDials int32
+	}{
+		{name: "no TLS config"},
+		{name: "TLS config", opts: []ClientOption{WithTLSConfig(trusted)}, wantHTTP: true, wantWS: true},
+		{
+			name:      "dialer without TLS settings",
+			opts:      []ClientOption{WithWebSocketDialer(plainDialer), WithTLSConfig(trusted)},
+			wantHTTP:  true,
+			wantWS:    true,
+			wantDials: 1,
+		},
+		{
+			name:     "dialer TLS settings take precedence",
+			opts:     []ClientOption{WithTLSConfig(trusted), WithWebSocketDialer(untrustingDialer)},
+			wantHTTP: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dials.Store(0)
+			client, _ := NewClient(append([]ClientOption{WithAPIKey("test-key"), WithBaseURL(server.URL)}, tt.opts...)...)
+			client.wsURL = "wss" + strings.TrimPrefix(server.URL, "https")
+
+			_, err := client.Credits.Get(context.Background())
+			if gotHTTP := err == nil; gotHTTP != tt.wantHTTP {
+				t.Errorf("expected HTTP success %v, got error %v", tt.wantHTTP, err)
+			}
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if err == nil {
+				stream.Close()
+			}
+			if gotWS := err == nil; gotWS != tt.wantWS {
+				t.Errorf("expected WebSocket success %v, got error %v", tt.wantWS, err)
+			}
+			if got := dials.Load(); got != tt.wantDials {
+				t.Errorf("expected %d dials through the custom dialer, got %d", tt.wantDials, got)
+			}
+		})
+	}
+
+	t.Run("caller values unmodified", func(t *testing.T) {
+		transport := &http.Transport{}
+		httpClient := &http.Client{Transport: transport}
+		client, _ := NewClient(WithAPIKey("test-key"), WithHTTPClient(httpClient), WithWebSocketDialer(plainDialer), WithTLSConfig(trusted))
+
+		if httpClient.Transport != transport || transport.TLSClientConfig == trusted {
+			t.Error("expected the caller's HTTP client and transport to be left unmodified")
+		}
+		if plainDialer.TLSClientConfig != nil {
+			t.Error("expected the caller's dialer to be left unmodified")
+		}
+		if got := client.httpClient.Transport.(*http.Transport).TLSClientConfig; got != trusted {
+			t.Error("expected the client's transport to use the TLS config")
+		}
+	})
+}
+
+func TestWithDefaultModels(t *testing.T) {
+	models := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup struct {
+			ModelName string `json:"model_name"`
+		}
+		conn.ReadJSON(&setup)
+		models <- setup.ModelName
+	}))
+	defer server.Close()
+
+	clientOpts := []ClientOption{WithDefaultTTSModel("fast-v2"), WithDefaultSTTModel("accurate-v3")}
+
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		stt      bool
+		params   string
+		expected string
+	}{
+		{name: "tts hardcoded default", expected: DefaultModelName},
+		{name: "tts client default", opts: clientOpts, expected: "fast-v2"},
+		{name: "tts explicit params", opts: clientOpts, params: "custom", expected: "custom"},
+		{name: "stt hardcoded default", stt: true, expected: DefaultModelName},
+		{name: "stt client default", opts: clientOpts, stt: true, expected: "accurate-v3"},
+		{name: "stt explicit params", opts: clientOpts, stt: true, params: "custom", expected: "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := NewClient(append([]ClientOption{WithAPIKey("test-key"), WithBaseURL(server.URL)}, tt.opts...)...)
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			var err error
+			if tt.stt {
+				var stream *STTStream
+				stream, err = client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM, ModelName: tt.params})
+				if err == nil {
+					defer stream.Close()
+				}
+			} else {
+				var stream *TTSStream
+				stream, err = client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM, ModelName: tt.params})
+				if err == nil {
+					defer stream.Close()
+				}
+			}
+			if err != nil {
+				t.Fatalf("Stream failed: %v", err)
+			}
+
+			select {
+			case got := <-models:
+				if got != tt.expected {
+					t.Errorf("expected model %q, got %q", tt.expected, got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for setup message")
+			}
+		})
+	}
+}
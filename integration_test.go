@@ -0,0 +1,151 @@
+//go:build integration
+
+package gradium
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newMockGradiumServer starts a local server speaking the Gradium WebSocket
+// protocol. Its TTS "synthesises" text by sending the UTF-8 bytes back as
+// audio, and its STT "transcribes" audio by decoding those bytes into words,
+// so a TTS to STT round trip reproduces the original text.
+func newMockGradiumServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/speech/tts", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		if err := conn.ReadJSON(&setup); err != nil {
+			return
+		}
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "tts-req"})
+
+		var text strings.Builder
+		for {
+			var msg ttsTextMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+			text.WriteString(msg.Text)
+		}
+
+		conn.WriteJSON(map[string]string{
+			"type":  "audio",
+			"audio": base64.StdEncoding.EncodeToString([]byte(text.String())),
+		})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	})
+
+	mux.HandleFunc("/speech/stt", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		if err := conn.ReadJSON(&setup); err != nil {
+			return
+		}
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "stt-req",
+			"model_name":        setup.ModelName,
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   0,
+			"text_stream_names": []string{"main"},
+		})
+
+		var audio []byte
+		for {
+			var msg sttAudioMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+			decoded, err := base64.StdEncoding.DecodeString(msg.Audio)
+			if err != nil {
+				return
+			}
+			audio = append(audio, decoded...)
+		}
+
+		for i, word := range strings.Fields(string(audio)) {
+			conn.WriteJSON(map[string]interface{}{"type": "text", "text": word, "start_s": float64(i) * 0.5})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestIntegration_TTSToSTTRoundTrip(t *testing.T) {
+	server := newMockGradiumServer(t)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const text = "The quick brown fox jumps over the lazy dog."
+
+	// Synthesise the text and save it, as an application would
+	speech, err := client.TTS.Create(ctx, TTSParams{
+		VoiceID:      "YTpq7expH9539ERJ",
+		OutputFormat: FormatPCM,
+		Text:         text,
+	})
+	if err != nil {
+		t.Fatalf("TTS.Create failed: %v", err)
+	}
+	if speech.RequestID != "tts-req" {
+		t.Errorf("expected TTS request ID 'tts-req', got %q", speech.RequestID)
+	}
+
+	path := filepath.Join(t.TempDir(), "speech.pcm")
+	if err := os.WriteFile(path, speech.RawData, 0o600); err != nil {
+		t.Fatalf("failed to write audio: %v", err)
+	}
+
+	// Load the file back and transcribe it
+	audio, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audio: %v", err)
+	}
+
+	transcript, err := client.STT.Transcribe(ctx, STTParams{InputFormat: InputFormatPCM}, audio)
+	if err != nil {
+		t.Fatalf("STT.Transcribe failed: %v", err)
+	}
+
+	if transcript != text {
+		t.Errorf("expected transcript %q, got %q", text, transcript)
+	}
+}
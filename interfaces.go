@@ -0,0 +1,74 @@
+package gradium
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// TTSClientIface is the method set of TTSService. Depend on it instead of
+// *TTSService to substitute a fake, such as MockClient, in tests.
+type TTSClientIface interface {
+	Create(ctx context.Context, params TTSParams) (*TTSResult, error)
+	Stream(ctx context.Context, params TTSParams, opts ...TTSStreamOption) (*TTSStream, error)
+	CreateBatch(ctx context.Context, inputs []TTSParams, opts BatchOptions) (<-chan BatchTTSResult, error)
+	CreateAsync(ctx context.Context, params TTSParams) (<-chan AsyncTTSResult, error)
+}
+
+// STTClientIface is the method set of STTService.
+type STTClientIface interface {
+	Stream(ctx context.Context, params STTParams, opts ...STTStreamOption) (*STTStream, error)
+	Transcribe(ctx context.Context, params STTParams, audio []byte) (string, error)
+	TranscribeDetailed(ctx context.Context, params STTParams, audio []byte) ([]STTTextResult, error)
+	TranscribeReader(ctx context.Context, params STTParams, r io.Reader) (string, error)
+	StreamReader(ctx context.Context, params STTParams, r io.Reader) (*STTStream, error)
+	VADOnly(ctx context.Context, params STTParams, audio []byte) ([]STTStepResult, error)
+}
+
+// VoicesClientIface is the method set of VoicesService.
+type VoicesClientIface interface {
+	List(ctx context.Context, params *VoiceListParams) ([]Voice, error)
+	ListPage(ctx context.Context, params *VoiceListParams) (*VoiceListResponse, error)
+	Iter(ctx context.Context, params *VoiceListParams) *VoiceIter
+	Search(ctx context.Context, query string) ([]Voice, error)
+	Get(ctx context.Context, voiceUID string) (*Voice, error)
+	GetByName(ctx context.Context, name string) (*Voice, error)
+	Preview(ctx context.Context, voiceUID, text string, format OutputFormat) (*TTSResult, error)
+	Create(ctx context.Context, audioData io.Reader, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error)
+	CreateFromURL(ctx context.Context, audioURL, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error)
+	Update(ctx context.Context, voiceUID string, params VoiceUpdateParams) (*Voice, error)
+	Delete(ctx context.Context, voiceUID string) error
+	DeleteBatch(ctx context.Context, uids []string) []error
+	UpdateBatch(ctx context.Context, updates []VoiceBatchUpdate) ([]Voice, []error)
+}
+
+// CreditsClientIface is the method set of CreditsService.
+type CreditsClientIface interface {
+	Get(ctx context.Context) (*CreditsSummary, error)
+	Watch(ctx context.Context, interval time.Duration) (<-chan CreditsSummary, <-chan error)
+	EstimateCost(ctx context.Context, params TTSParams) (*CostEstimate, error)
+}
+
+// Services is an interface-typed view of a client's services. Client.Services
+// and MockClient.Services both return one, so code that takes a Services runs
+// unchanged against the API or against a mock.
+type Services struct {
+	TTS     TTSClientIface
+	STT     STTClientIface
+	Voices  VoicesClientIface
+	Credits CreditsClientIface
+}
+
+// Services returns the client's services behind their interfaces. The TTS,
+// STT, Voices and Credits fields stay concrete so that code using them as
+// *TTSService and friends keeps compiling.
+func (c *Client) Services() Services {
+	return Services{TTS: c.TTS, STT: c.STT, Voices: c.Voices, Credits: c.Credits}
+}
+
+var (
+	_ TTSClientIface     = (*TTSService)(nil)
+	_ STTClientIface     = (*STTService)(nil)
+	_ VoicesClientIface  = (*VoicesService)(nil)
+	_ CreditsClientIface = (*CreditsService)(nil)
+)
@@ -0,0 +1,171 @@
+package gradium
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RequestLogger observes HTTP requests made by the client.
+type RequestLogger interface {
+	// LogRequest is called before a request is sent.
+	LogRequest(req *http.Request)
+	// LogResponse is called once the request completes. resp is nil if err is set.
+	LogResponse(req *http.Request, resp *http.Response, elapsed time.Duration, err error)
+}
+
+// WithRequestLogger installs a RequestLogger around the client's HTTP transport.
+func WithRequestLogger(l RequestLogger) ClientOption {
+	return func(c *Client) {
+		c.requestLogger = l
+	}
+}
+
+// loggingTransport is an http.RoundTripper that reports to a RequestLogger.
+type loggingTransport struct {
+	next   http.RoundTripper
+	logger RequestLogger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.logger.LogRequest(req)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.logger.LogResponse(req, resp, time.Since(start), err)
+	return resp, err
+}
+
+// StdRequestLogger returns a RequestLogger that writes one line per completed
+// request to w in Common Log Format, followed by the request duration:
+//
+//	eu.api.gradium.ai - - [10/Oct/2025:13:55:36 +0000] "GET /api/voices/ HTTP/1.1" 200 2326 0.123s
+//
+// Failed requests are logged with a status of "-".
+func StdRequestLogger(w io.Writer) RequestLogger {
+	return &stdRequestLogger{w: w}
+}
+
+type stdRequestLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *stdRequestLogger) LogRequest(_ *http.Request) {}
+
+func (l *stdRequestLogger) LogResponse(req *http.Request, resp *http.Response, elapsed time.Duration, _ error) {
+	status, size := "-", "-"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+		if resp.ContentLength >= 0 {
+			size = strconv.FormatInt(resp.ContentLength, 10)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = fmt.Fprintf(l.w, "%s - - [%s] \"%s %s %s\" %s %s %.3fs\n",
+		req.URL.Host,
+		time.Now().Format("02/Jan/2006:15:04:05 -0700"),
+		req.Method,
+		req.URL.RequestURI(),
+		req.Proto,
+		status,
+		size,
+		elapsed.Seconds(),
+	)
+}
+
+// WithLogger logs SDK activity to logger: HTTP requests with their method,
+// URL, status, duration and request ID at INFO, WebSocket lifecycle events
+// (connected, ready, first_chunk, stream_complete) at INFO, each audio chunk
+// at DEBUG, retries and dropped stream messages at WARN, and failures at
+// ERROR. Request headers, and so the API key, are never logged. Without a
+// logger the SDK logs nothing.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// Events logged by streams.
+const (
+	logEventConnected      = "connected"
+	logEventReady          = "ready"
+	logEventFirstChunk     = "first_chunk"
+	logEventAudioChunk     = "audio_chunk"
+	logEventStreamComplete = "stream_complete"
+	logEventUnknownMessage = "unknown_message"
+	logEventHandshake      = "handshake_failed"
+)
+
+// Attribute keys used in log records.
+const (
+	logKeyService  = "service"
+	logKeyBytes    = "bytes"
+	logKeyError    = "error"
+	logKeyDuration = "duration"
+	logKeyType     = "type"
+)
+
+// discardLogger is used when no logger is configured.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// log returns the client's logger, or one that discards everything.
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+	return c.logger
+}
+
+// slogRequestLogger is a RequestLogger that writes to an slog.Logger.
+type slogRequestLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogRequestLogger) LogRequest(_ *http.Request) {}
+
+func (l slogRequestLogger) LogResponse(req *http.Request, resp *http.Response, elapsed time.Duration, err error) {
+	if err != nil {
+		l.logger.Error("http request failed",
+			"method", req.Method, "url", req.URL.Redacted(), logKeyDuration, elapsed, logKeyError, err)
+		return
+	}
+	l.logger.Info("http request",
+		"method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode, logKeyDuration, elapsed,
+		AttrRequestID, resp.Header.Get("x-request-id"))
+}
+
+// sensitiveHeaders are replaced by redactHeaders.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// redactHeaders returns a copy of h safe to log, with the values of
+// credential-bearing headers replaced.
+func redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = []string{"REDACTED"}
+		}
+	}
+	return redacted
+}
+
+// logDropped warns that a stream message was dropped because channel was
+// full.
+func logDropped(logger *slog.Logger, channel string) {
+	logger.Warn("dropped stream message", "channel", channel)
+}
+
+// logStreamComplete logs the end of a stream, at ERROR if it failed.
+func logStreamComplete(logger *slog.Logger, audioBytes int64, err error) {
+	if err != nil {
+		logger.Error(logEventStreamComplete, AttrAudioBytesTransferred, audioBytes, logKeyError, err)
+		return
+	}
+	logger.Info(logEventStreamComplete, AttrAudioBytesTransferred, audioBytes)
+}
@@ -0,0 +1,451 @@
+package gradium
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingLogger struct {
+	mu        sync.Mutex
+	requests  []string
+	responses []int
+	errs      []error
+}
+
+func (l *recordingLogger) LogRequest(req *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requests = append(l.requests, req.Method+" "+req.URL.Path)
+}
+
+func (l *recordingLogger) LogResponse(_ *http.Request, resp *http.Response, _ time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	l.responses = append(l.responses, status)
+	l.errs = append(l.errs, err)
+}
+
+func TestWithRequestLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/voices/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"detail": "Voice not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(CreditsSummary{})
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRequestLogger(logger))
+
+	if _, err := client.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Voices.Get(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	expectedRequests := []string{"GET /usages/credits", "GET /voices/missing"}
+	if len(logger.requests) != len(expectedRequests) {
+		t.Fatalf("expected %d logged requests, got %d", len(expectedRequests), len(logger.requests))
+	}
+	for i, req := range expectedRequests {
+		if logger.requests[i] != req {
+			t.Errorf("expected request %q, got %q", req, logger.requests[i])
+		}
+	}
+
+	expectedStatuses := []int{http.StatusOK, http.StatusNotFound}
+	for i, status := range expectedStatuses {
+		if logger.responses[i] != status {
+			t.Errorf("expected status %d, got %d", status, logger.responses[i])
+		}
+	}
+}
+
+func TestWithRequestLoggerKeepsCustomHTTPClient(t *testing.T) {
+	customClient := &http.Client{Timeout: 10 * time.Second}
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithHTTPClient(customClient),
+		WithRequestLogger(&recordingLogger{}),
+	)
+
+	if customClient.Transport != nil {
+		t.Error("expected caller's HTTP client to be left unmodified")
+	}
+	if client.httpClient.Timeout != 10*time.Second {
+		t.Errorf("expected timeout 10s, got %v", client.httpClient.Timeout)
+	}
+	if _, ok := client.httpClient.Transport.(*loggingTransport); !ok {
+		t.Errorf("expected logging transport, got %T", client.httpClient.Transport)
+	}
+}
+
+func TestStdRequestLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Length", "2")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRequestLogger(StdRequestLogger(&buf)))
+
+	if _, err := client.Voices.List(context.Background(), &VoiceListParams{Limit: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pattern := regexp.MustCompile(`^127\.0\.0\.1:\d+ - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /voices/\?limit=5 HTTP/1\.1" 200 2 \d+\.\d{3}s\n$`)
+	if !pattern.MatchString(buf.String()) {
+		t.Errorf("unexpected log line: %q", buf.String())
+	}
+}
+
+func TestStdRequestLoggerConnectionError(t *testing.T) {
+	var buf bytes.Buffer
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:1"), WithRequestLogger(StdRequestLogger(&buf)))
+
+	if _, err := client.Credits.Get(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	pattern := regexp.MustCompile(`"GET /usages/credits HTTP/1\.1" - - \d+\.\d{3}s\n$`)
+	if !pattern.MatchString(buf.String()) {
+		t.Errorf("unexpected log line: %q", buf.String())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of stream
+// goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records decodes the JSON log records written so far.
+func (b *syncBuffer) records(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(b.buf.Bytes()))
+	for dec.More() {
+		var r map[string]interface{}
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("invalid log record: %v", err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// findRecord returns the first record with the given message, or nil.
+func findRecord(records []map[string]interface{}, msg string) map[string]interface{} {
+	for _, r := range records {
+		if r["msg"] == msg {
+			return r
+		}
+	}
+	return nil
+}
+
+func newJSONLogger(buf *syncBuffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestWithLoggerHTTP(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("x-request-id", "req-42")
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: 100})
+	}))
+	defer server.Close()
+
+	var buf syncBuffer
+	client, _ := NewClient(
+		WithAPIKey("secret-test-key"),
+		WithBaseURL(server.URL),
+		WithLogger(newJSONLogger(&buf)),
+		WithRetry(fastRetry),
+	)
+
+	if _, err := client.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := buf.records(t)
+	tests := []struct {
+		msg   string
+		level string
+		attrs map[string]interface{}
+	}{
+		{
+			msg:   "http request",
+			level: "INFO",
+			attrs: map[string]interface{}{"method": "GET", "url": server.URL + "/usages/credits", "status": 503.0, "request_id": "req-42"},
+		},
+		{
+			msg:   "retrying request",
+			level: "WARN",
+			attrs: map[string]interface{}{"attempt": 1.0},
+		},
+	}
+	for _, tt := range tests {
+		r := findRecord(records, tt.msg)
+		if r == nil {
+			t.Errorf("no %q record in %v", tt.msg, records)
+			continue
+		}
+		if r["level"] != tt.level {
+			t.Errorf("%q: expected level %s, got %v", tt.msg, tt.level, r["level"])
+		}
+		for k, want := range tt.attrs {
+			if r[k] != want {
+				t.Errorf("%q: expected %s=%v, got %v", tt.msg, k, want, r[k])
+			}
+		}
+		if _, ok := r["duration"]; tt.msg == "http request" && !ok {
+			t.Errorf("%q: missing duration", tt.msg)
+		}
+	}
+
+	if strings.Contains(buf.String(), "secret-test-key") {
+		t.Error("API key was logged")
+	}
+}
+
+func TestWithLoggerHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+
+	var buf syncBuffer
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithLogger(newJSONLogger(&buf)))
+
+	if _, err := client.Credits.Get(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+
+	r := findRecord(buf.records(t), "http request failed")
+	if r == nil || r["level"] != "ERROR" || r["error"] == nil {
+		t.Errorf("expected an ERROR record with the error, got %v", r)
+	}
+}
+
+func TestWithLoggerTTSStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		for _, chunk := range []string{"abc", "defg"} {
+			conn.WriteJSON(map[string]string{
+				"type":  "audio",
+				"audio": base64.StdEncoding.EncodeToString([]byte(chunk)),
+			})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	var buf syncBuffer
+	client, _ := NewClient(WithAPIKey("secret-test-key"), WithBaseURL(server.URL), WithLogger(newJSONLogger(&buf)))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	for range stream.Audio() {
+	}
+	<-stream.DoneErr()
+
+	records := buf.records(t)
+	tests := []struct {
+		msg   string
+		level string
+		key   string
+		want  interface{}
+	}{
+		{msg: "connected", level: "INFO", key: "service", want: "TTS"},
+		{msg: "ready", level: "INFO", key: "request_id", want: "req-123"},
+		{msg: "first_chunk", level: "INFO", key: "bytes", want: 3.0},
+		{msg: "audio_chunk", level: "DEBUG", key: "bytes", want: 3.0},
+		{msg: "stream_complete", level: "INFO", key: "audio_bytes_transferred", want: 7.0},
+	}
+	for _, tt := range tests {
+		r := findRecord(records, tt.msg)
+		if r == nil {
+			t.Errorf("no %q record in %v", tt.msg, records)
+			continue
+		}
+		if r["level"] != tt.level || r[tt.key] != tt.want {
+			t.Errorf("%q: expected level %s and %s=%v, got %v", tt.msg, tt.level, tt.key, tt.want, r)
+		}
+	}
+
+	if strings.Contains(buf.String(), "secret-test-key") {
+		t.Error("API key was logged")
+	}
+}
+
+func TestWithLoggerUnknownMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		run     func(t *testing.T, client *Client)
+	}{
+		{
+			name:    "STT",
+			service: "STT",
+			run: func(t *testing.T, client *Client) {
+				stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				defer stream.Close()
+				for range stream.All() {
+				}
+			},
+		},
+		{
+			name:    "TTS",
+			service: "TTS",
+			run: func(t *testing.T, client *Client) {
+				stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				defer stream.Close()
+				<-stream.DoneErr()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup map[string]interface{}
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]interface{}{"type": "metrics", "rtf": 0.5})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			var buf syncBuffer
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithLogger(newJSONLogger(&buf)))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			tt.run(t, client)
+
+			r := findRecord(buf.records(t), "unknown_message")
+			if r == nil {
+				t.Fatalf("no unknown_message record in %s", buf.String())
+			}
+			if r["level"] != "DEBUG" || r["type"] != "metrics" || r["service"] != tt.service {
+				t.Errorf("expected DEBUG record with type metrics for %s, got %v", tt.service, r)
+			}
+		})
+	}
+}
+
+func TestWithLoggerHandshakeFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.Header().Set("X-Api-Key", "echoed-secret")
+		w.Header().Set("Set-Cookie", "session=cookie-secret")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var buf syncBuffer
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithLogger(newJSONLogger(&buf)))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	if _, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM}); err == nil {
+		t.Fatal("expected the handshake to fail")
+	}
+
+	r := findRecord(buf.records(t), "handshake_failed")
+	if r == nil {
+		t.Fatalf("no handshake_failed record in %s", buf.String())
+	}
+	if r["level"] != "DEBUG" || r["status"] != 403.0 || r["service"] != "STT" {
+		t.Errorf("expected DEBUG record with status 403 for STT, got %v", r)
+	}
+	headers, _ := r["headers"].(map[string]interface{})
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "X-Request-Id", want: "req-42"},
+		{header: "X-Api-Key", want: "REDACTED"},
+		{header: "Set-Cookie", want: "REDACTED"},
+	}
+	for _, tt := range tests {
+		values, _ := headers[tt.header].([]interface{})
+		if len(values) != 1 || values[0] != tt.want {
+			t.Errorf("%s: expected [%s], got %v", tt.header, tt.want, headers[tt.header])
+		}
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Error("credential header was logged")
+	}
+}
+
+func TestWithLoggerUnset(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	if client.log().Enabled(context.Background(), slog.LevelError) {
+		t.Error("expected the default logger to discard everything")
+	}
+}
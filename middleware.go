@@ -0,0 +1,28 @@
+package gradium
+
+import "net/http"
+
+// MiddlewareFunc intercepts an HTTP request made by the client. It may
+// modify req before passing it to next, and inspect or replace the response.
+type MiddlewareFunc func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// WithMiddleware installs middleware around the client's HTTP transport for
+// every VoicesService and CreditsService call. Middleware runs in the order
+// given, the first one seeing the request first. It wraps the transport
+// rather than replacing the HTTP client, so WithTimeout and WithHTTPClient
+// keep working. Repeated uses append to the chain.
+func WithMiddleware(middleware ...MiddlewareFunc) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// middlewareTransport is an http.RoundTripper that runs a MiddlewareFunc.
+type middlewareTransport struct {
+	fn   MiddlewareFunc
+	next http.RoundTripper
+}
+
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.fn(req, t.next)
+}
@@ -0,0 +1,129 @@
+package gradium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var gotHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		if r.URL.Path == "/voices/" {
+			json.NewEncoder(w).Encode([]Voice{})
+			return
+		}
+		json.NewEncoder(w).Encode(CreditsSummary{})
+	}))
+	defer server.Close()
+
+	var order []string
+	var statuses []int
+	tag := func(name, header string) MiddlewareFunc {
+		return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+			order = append(order, name)
+			req.Header.Set(header, "value-"+name)
+			resp, err := next.RoundTrip(req)
+			if resp != nil {
+				statuses = append(statuses, resp.StatusCode)
+			}
+			return resp, err
+		}
+	}
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMiddleware(tag("correlation", "X-Correlation-ID"), tag("request", "X-Request-ID")),
+	)
+
+	if _, err := client.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gotHeaders.Get("X-Correlation-ID"); got != "value-correlation" {
+		t.Errorf("expected X-Correlation-ID header, got %q", got)
+	}
+	if got := gotHeaders.Get("X-Request-ID"); got != "value-request" {
+		t.Errorf("expected X-Request-ID header, got %q", got)
+	}
+
+	if _, err := client.Voices.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantOrder := []string{"correlation", "request", "correlation", "request"}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("expected middleware order %v, got %v", wantOrder, order)
+	}
+	if len(statuses) != 4 {
+		t.Errorf("expected every middleware to see every response, got %v", statuses)
+	}
+}
+
+func TestWithMiddlewareKeepsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	passThrough := func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		return next.RoundTrip(req)
+	}
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMiddleware(passThrough),
+		WithTimeout(50*time.Millisecond),
+	)
+
+	_, err := client.Credits.Get(context.Background())
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected TimeoutError, got %v", err)
+	}
+}
+
+func TestWithMiddlewareSeesLoggedHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(CreditsSummary{})
+	}))
+	defer server.Close()
+
+	var logged string
+	logger := &headerLogger{header: "X-Request-ID", got: &logged}
+	addID := func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		req.Header.Set("X-Request-ID", "abc")
+		return next.RoundTrip(req)
+	}
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithRequestLogger(logger),
+		WithMiddleware(addID),
+	)
+
+	if _, err := client.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logged != "abc" {
+		t.Errorf("expected logger to see middleware header, got %q", logged)
+	}
+}
+
+type headerLogger struct {
+	header string
+	got    *string
+}
+
+func (l *headerLogger) LogRequest(req *http.Request) {
+	*l.got = req.Header.Get(l.header)
+}
+
+func (l *headerLogger) LogResponse(*http.Request, *http.Response, time.Duration, error) {}
@@ -0,0 +1,539 @@
+package gradium
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockClient is an in-memory fake of the Gradium API for unit-testing code
+// built on the SDK. Its TTS, STT, Voices and Credits fields implement the
+// service interfaces, so code that depends on TTSClientIface and friends can
+// be handed a MockClient's services instead of a Client's. Every call is
+// recorded for later assertions.
+//
+// Streaming is not simulated: Stream methods return an error.
+//
+// Example:
+//
+//	mock := gradium.NewMockClient(
+//	    gradium.WithMockTTSResult("YTpq7expH9539ERJ", &gradium.TTSResult{RawData: audio}),
+//	)
+//	speak(ctx, mock.TTS) // code under test, taking a gradium.TTSClientIface
+//	if !mock.CreateWasCalled("YTpq7expH9539ERJ") {
+//	    t.Error("expected speech to be synthesised")
+//	}
+type MockClient struct {
+	TTS     TTSClientIface
+	STT     STTClientIface
+	Voices  VoicesClientIface
+	Credits CreditsClientIface
+
+	mu          sync.Mutex
+	calls       []MockCall
+	errs        map[string]error
+	ttsResults  map[string]*TTSResult
+	transcript  []STTTextResult
+	vadSteps    []STTStepResult
+	voices      []Voice
+	nextVoiceID int
+	credits     CreditsSummary
+}
+
+// MockCall records one call made on a MockClient service.
+type MockCall struct {
+	// Method is the qualified method name, such as "TTS.Create".
+	Method string
+	// Args holds the call's arguments, excluding the context.
+	Args []interface{}
+}
+
+// MockOption configures a MockClient.
+type MockOption func(*MockClient)
+
+// WithMockTTSResult makes TTS.Create return result for voiceID. An empty
+// voiceID sets the result for voices without one of their own.
+func WithMockTTSResult(voiceID string, result *TTSResult) MockOption {
+	return func(m *MockClient) {
+		m.ttsResults[voiceID] = result
+	}
+}
+
+// WithMockTranscript sets the results returned by STT.TranscribeDetailed.
+// STT.Transcribe returns their text joined with spaces.
+func WithMockTranscript(results []STTTextResult) MockOption {
+	return func(m *MockClient) {
+		m.transcript = results
+	}
+}
+
+// WithMockVADSteps sets the steps returned by STT.VADOnly.
+func WithMockVADSteps(steps []STTStepResult) MockOption {
+	return func(m *MockClient) {
+		m.vadSteps = steps
+	}
+}
+
+// WithMockVoices seeds the voices served by the Voices methods. Create,
+// Update and Delete modify this set.
+func WithMockVoices(voices []Voice) MockOption {
+	return func(m *MockClient) {
+		m.voices = append([]Voice(nil), voices...)
+	}
+}
+
+// WithMockCredits sets the summary returned by Credits.Get and Watch.
+func WithMockCredits(credits CreditsSummary) MockOption {
+	return func(m *MockClient) {
+		m.credits = credits
+	}
+}
+
+// WithMockError makes method, such as "Voices.Get", fail with err. The call
+// is still recorded.
+func WithMockError(method string, err error) MockOption {
+	return func(m *MockClient) {
+		m.errs[method] = err
+	}
+}
+
+// NewMockClient creates a MockClient.
+func NewMockClient(opts ...MockOption) *MockClient {
+	m := &MockClient{
+		errs:       make(map[string]error),
+		ttsResults: make(map[string]*TTSResult),
+	}
+	m.TTS = &mockTTS{m: m}
+	m.STT = &mockSTT{m: m}
+	m.Voices = &mockVoices{m: m}
+	m.Credits = &mockCredits{m: m}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Services returns the mock's services, for code that takes a Services.
+func (m *MockClient) Services() Services {
+	return Services{TTS: m.TTS, STT: m.STT, Voices: m.Voices, Credits: m.Credits}
+}
+
+// Calls returns the calls made so far, in order.
+func (m *MockClient) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+// WasCalled reports whether method, such as "Credits.Get", was called.
+func (m *MockClient) WasCalled(method string) bool {
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWasCalled reports whether TTS.Create was called for voiceID.
+func (m *MockClient) CreateWasCalled(voiceID string) bool {
+	for _, call := range m.Calls() {
+		if call.Method == mockTTSCreate && call.Args[0].(TTSParams).VoiceID == voiceID {
+			return true
+		}
+	}
+	return false
+}
+
+// TranscribeWasCalled reports whether STT.Transcribe was called.
+func (m *MockClient) TranscribeWasCalled() bool {
+	return m.WasCalled(mockSTTTranscribe)
+}
+
+// record logs a call and returns the error configured for method, if any.
+// It must be called without m.mu held.
+func (m *MockClient) record(method string, args ...interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+	return m.errs[method]
+}
+
+// Methods queried by the MockClient assertion helpers.
+const (
+	mockTTSCreate     = "TTS.Create"
+	mockSTTTranscribe = "STT.Transcribe"
+)
+
+// errStreamingNotMocked is returned by the mock Stream methods.
+var errStreamingNotMocked = &Error{Message: "streaming is not supported by MockClient"}
+
+type mockTTS struct{ m *MockClient }
+
+func (s *mockTTS) Create(_ context.Context, params TTSParams) (*TTSResult, error) {
+	if err := s.m.record(mockTTSCreate, params); err != nil {
+		return nil, err
+	}
+	return s.m.ttsResult(params.VoiceID)
+}
+
+// ttsResult returns the canned TTS result for voiceID, falling back to the
+// one registered for the empty voice ID.
+func (m *MockClient) ttsResult(voiceID string) (*TTSResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result, ok := m.ttsResults[voiceID]
+	if !ok {
+		result, ok = m.ttsResults[""]
+	}
+	if !ok {
+		return nil, &NotFoundError{Message: fmt.Sprintf("no mock TTS result for voice %q", voiceID)}
+	}
+	return result, nil
+}
+
+func (s *mockTTS) Stream(_ context.Context, params TTSParams, _ ...TTSStreamOption) (*TTSStream, error) {
+	if err := s.m.record("TTS.Stream", params); err != nil {
+		return nil, err
+	}
+	return nil, errStreamingNotMocked
+}
+
+func (s *mockTTS) CreateBatch(ctx context.Context, inputs []TTSParams, opts BatchOptions) (<-chan BatchTTSResult, error) {
+	return createBatch(ctx, s.Create, inputs, opts)
+}
+
+func (s *mockTTS) CreateAsync(ctx context.Context, params TTSParams) (<-chan AsyncTTSResult, error) {
+	return createAsync(ctx, s.Create, params)
+}
+
+type mockSTT struct{ m *MockClient }
+
+func (s *mockSTT) Stream(_ context.Context, params STTParams, _ ...STTStreamOption) (*STTStream, error) {
+	if err := s.m.record("STT.Stream", params); err != nil {
+		return nil, err
+	}
+	return nil, errStreamingNotMocked
+}
+
+func (s *mockSTT) Transcribe(_ context.Context, params STTParams, audio []byte) (string, error) {
+	if err := s.m.record(mockSTTTranscribe, params, audio); err != nil {
+		return "", err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	return joinText(s.m.transcript), nil
+}
+
+func (s *mockSTT) TranscribeReader(_ context.Context, params STTParams, r io.Reader) (string, error) {
+	audio, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if err := s.m.record("STT.TranscribeReader", params, audio); err != nil {
+		return "", err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	return joinText(s.m.transcript), nil
+}
+
+func (s *mockSTT) StreamReader(_ context.Context, params STTParams, _ io.Reader) (*STTStream, error) {
+	if err := s.m.record("STT.StreamReader", params); err != nil {
+		return nil, err
+	}
+	return nil, errStreamingNotMocked
+}
+
+func (s *mockSTT) TranscribeDetailed(_ context.Context, params STTParams, audio []byte) ([]STTTextResult, error) {
+	if err := s.m.record("STT.TranscribeDetailed", params, audio); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	return append([]STTTextResult(nil), s.m.transcript...), nil
+}
+
+func (s *mockSTT) VADOnly(_ context.Context, params STTParams, audio []byte) ([]STTStepResult, error) {
+	if err := s.m.record("STT.VADOnly", params, audio); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	return append([]STTStepResult(nil), s.m.vadSteps...), nil
+}
+
+type mockVoices struct{ m *MockClient }
+
+func (s *mockVoices) List(_ context.Context, params *VoiceListParams) ([]Voice, error) {
+	if err := s.m.record("Voices.List", params); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	return append([]Voice(nil), s.m.voices...), nil
+}
+
+func (s *mockVoices) ListPage(_ context.Context, params *VoiceListParams) (*VoiceListResponse, error) {
+	if err := s.m.record("Voices.ListPage", params); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	voices := append([]Voice(nil), s.m.voices...)
+	return &VoiceListResponse{Voices: voices, Total: len(voices)}, nil
+}
+
+// Iter iterates over the mock voices as a single page, recorded as a
+// Voices.ListPage call when the first Next fetches it.
+func (s *mockVoices) Iter(ctx context.Context, params *VoiceListParams) *VoiceIter {
+	return newVoiceIter(ctx, params, s.ListPage)
+}
+
+// Search returns the mock voices whose name contains query, ignoring case.
+func (s *mockVoices) Search(_ context.Context, query string) ([]Voice, error) {
+	if err := s.m.record("Voices.Search", query); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	var voices []Voice
+	for _, v := range s.m.voices {
+		if strings.Contains(strings.ToLower(v.Name), strings.ToLower(query)) {
+			voices = append(voices, v)
+		}
+	}
+	return voices, nil
+}
+
+func (s *mockVoices) GetByName(_ context.Context, name string) (*Voice, error) {
+	if err := s.m.record("Voices.GetByName", name); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	return exactVoiceMatch(slices.Clone(s.m.voices), name)
+}
+
+func (s *mockVoices) Get(_ context.Context, voiceUID string) (*Voice, error) {
+	if err := s.m.record("Voices.Get", voiceUID); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	i := s.m.voiceIndex(voiceUID)
+	if i < 0 {
+		return nil, voiceNotFound(voiceUID)
+	}
+	voice := s.m.voices[i]
+	return &voice, nil
+}
+
+// Preview returns the result registered with WithMockTTSResult for voiceUID.
+func (s *mockVoices) Preview(_ context.Context, voiceUID, text string, format OutputFormat) (*TTSResult, error) {
+	if err := validatePreview(text); err != nil {
+		return nil, err
+	}
+	if err := s.m.record("Voices.Preview", voiceUID, text, format); err != nil {
+		return nil, err
+	}
+	return s.m.ttsResult(voiceUID)
+}
+
+func (s *mockVoices) Create(_ context.Context, audioData io.Reader, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error) {
+	if err := s.m.record("Voices.Create", filename, params); err != nil {
+		return nil, err
+	}
+	if err := validateVoiceCreate(filename, params); err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(io.Discard, audioData); err != nil {
+		return nil, err
+	}
+
+	return s.addVoice(filename, params), nil
+}
+
+func (s *mockVoices) CreateFromURL(_ context.Context, audioURL, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error) {
+	if err := s.m.record("Voices.CreateFromURL", audioURL, filename, params); err != nil {
+		return nil, err
+	}
+	if err := validateVoiceCreate(filename, params); err != nil {
+		return nil, err
+	}
+
+	return s.addVoice(filename, params), nil
+}
+
+// addVoice stores a new voice built from the Create arguments.
+func (s *mockVoices) addVoice(filename string, params VoiceCreateParams) *VoiceCreateResponse {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	s.m.nextVoiceID++
+	voice := Voice{
+		UID:         fmt.Sprintf("mock-voice-%d", s.m.nextVoiceID),
+		Name:        params.Name,
+		Description: params.Description,
+		Language:    params.Language,
+		StartS:      params.StartS,
+		Filename:    filename,
+	}
+	s.m.voices = append(s.m.voices, voice)
+	uid := voice.UID
+	return &VoiceCreateResponse{UID: &uid, Voice: &voice}
+}
+
+func (s *mockVoices) Update(_ context.Context, voiceUID string, params VoiceUpdateParams) (*Voice, error) {
+	if err := s.m.record("Voices.Update", voiceUID, params); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	i := s.m.voiceIndex(voiceUID)
+	if i < 0 {
+		return nil, voiceNotFound(voiceUID)
+	}
+	voice := &s.m.voices[i]
+	if params.Name != nil {
+		voice.Name = *params.Name
+	}
+	if params.Description != nil {
+		voice.Description = params.Description
+	}
+	if params.Language != nil {
+		voice.Language = params.Language
+	}
+	if params.StartS != nil {
+		voice.StartS = *params.StartS
+	}
+	if params.Tags != nil {
+		voice.Tags = params.Tags
+	}
+	if params.Rank != nil {
+		voice.Rank = params.Rank
+	}
+	now := time.Now()
+	voice.UpdatedAt = &now
+	updated := *voice
+	return &updated, nil
+}
+
+func (s *mockVoices) Delete(_ context.Context, voiceUID string) error {
+	if err := s.m.record("Voices.Delete", voiceUID); err != nil {
+		return err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	i := s.m.voiceIndex(voiceUID)
+	if i < 0 {
+		return voiceNotFound(voiceUID)
+	}
+	s.m.voices = append(s.m.voices[:i], s.m.voices[i+1:]...)
+	return nil
+}
+
+// DeleteBatch calls Delete for each UID in order.
+func (s *mockVoices) DeleteBatch(ctx context.Context, uids []string) []error {
+	return forEachConcurrent(ctx, len(uids), 1, func(i int) error {
+		return s.Delete(ctx, uids[i])
+	})
+}
+
+// UpdateBatch calls Update for each update in order.
+func (s *mockVoices) UpdateBatch(ctx context.Context, updates []VoiceBatchUpdate) ([]Voice, []error) {
+	voices := make([]Voice, len(updates))
+	errs := forEachConcurrent(ctx, len(updates), 1, func(i int) error {
+		voice, err := s.Update(ctx, updates[i].UID, updates[i].VoiceUpdateParams)
+		if err != nil {
+			return err
+		}
+		voices[i] = *voice
+		return nil
+	})
+	return voices, errs
+}
+
+// voiceIndex returns the index of the voice with uid, or -1. m.mu must be
+// held.
+func (m *MockClient) voiceIndex(uid string) int {
+	for i, v := range m.voices {
+		if v.UID == uid {
+			return i
+		}
+	}
+	return -1
+}
+
+func voiceNotFound(uid string) error {
+	return &NotFoundError{Message: fmt.Sprintf("voice %q not found", uid)}
+}
+
+type mockCredits struct{ m *MockClient }
+
+func (s *mockCredits) Get(_ context.Context) (*CreditsSummary, error) {
+	if err := s.m.record("Credits.Get"); err != nil {
+		return nil, err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	credits := s.m.credits
+	return &credits, nil
+}
+
+// EstimateCost returns the EstimateTTSCreditsLocal estimate for params.
+func (s *mockCredits) EstimateCost(_ context.Context, params TTSParams) (*CostEstimate, error) {
+	if err := s.m.record("Credits.EstimateCost", params); err != nil {
+		return nil, err
+	}
+	return localCostEstimate(params), nil
+}
+
+// Watch delivers the mock summary once and closes both channels when ctx is
+// done, or delivers the error configured for "Credits.Watch", or the
+// ValidationError CreditsService.Watch returns for an interval below 1, and
+// closes them.
+func (s *mockCredits) Watch(ctx context.Context, interval time.Duration) (<-chan CreditsSummary, <-chan error) {
+	summaries := make(chan CreditsSummary, 1)
+	errs := make(chan error, 1)
+
+	err := s.m.record("Credits.Watch", interval)
+	if err == nil {
+		err = validateWatchInterval(interval)
+	}
+	if err != nil {
+		errs <- err
+		close(errs)
+		close(summaries)
+		return summaries, errs
+	}
+
+	s.m.mu.Lock()
+	summaries <- s.m.credits
+	s.m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		close(errs)
+		close(summaries)
+	}()
+
+	return summaries, errs
+}
@@ -0,0 +1,252 @@
+package gradium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// speak is an example of user code written against the service interfaces.
+func speak(ctx context.Context, tts TTSClientIface, text string) ([]byte, error) {
+	result, err := tts.Create(ctx, TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM, Text: text})
+	if err != nil {
+		return nil, err
+	}
+	return result.RawData, nil
+}
+
+// voiceNames is an example of user code that takes every service at once.
+func voiceNames(ctx context.Context, svc Services) ([]string, error) {
+	voices, err := svc.Voices.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, v := range voices {
+		names = append(names, v.Name)
+	}
+	return names, nil
+}
+
+func TestServices_ClientAndMockAreInterchangeable(t *testing.T) {
+	voices := []Voice{{UID: "voice-1", Name: "Voice 1"}, {UID: "voice-2", Name: "Voice 2"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(voices)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	tests := []struct {
+		name string
+		svc  Services
+	}{
+		{name: "client", svc: client.Services()},
+		{name: "mock", svc: NewMockClient(WithMockVoices(voices)).Services()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := voiceNames(context.Background(), tt.svc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := []string{"Voice 1", "Voice 2"}; !reflect.DeepEqual(names, want) {
+				t.Errorf("expected %v, got %v", want, names)
+			}
+		})
+	}
+}
+
+func TestMockClient_TTS(t *testing.T) {
+	mock := NewMockClient(WithMockTTSResult("voice-123", &TTSResult{RawData: []byte("audio")}))
+
+	audio, err := speak(context.Background(), mock.TTS, "Hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(audio) != "audio" {
+		t.Errorf("expected mock audio, got %q", audio)
+	}
+	if !mock.CreateWasCalled("voice-123") {
+		t.Error("expected Create to be recorded for voice-123")
+	}
+	if mock.CreateWasCalled("other-voice") {
+		t.Error("expected no Create call for other-voice")
+	}
+
+	_, err = mock.TTS.Create(context.Background(), TTSParams{VoiceID: "unknown"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected NotFoundError for unmocked voice, got %v", err)
+	}
+
+	clip, err := mock.Voices.Preview(context.Background(), "voice-123", "Hi", FormatWAV)
+	if err != nil || string(clip.RawData) != "audio" {
+		t.Errorf("expected Preview to return the mock audio, got %v, %v", clip, err)
+	}
+
+	if _, err := mock.TTS.Stream(context.Background(), TTSParams{}); err == nil {
+		t.Error("expected Stream to fail on the mock")
+	}
+
+	// The real client satisfies the same interface
+	client, _ := NewClient(WithAPIKey("test-key"))
+	var _ TTSClientIface = client.TTS
+}
+
+func TestMockClient_STT(t *testing.T) {
+	results := []STTTextResult{{Text: "Hello", StartS: 0}, {Text: "world", StartS: 0.5}}
+	mock := NewMockClient(WithMockTranscript(results))
+
+	if mock.TranscribeWasCalled() {
+		t.Error("expected no Transcribe call yet")
+	}
+
+	text, err := mock.STT.Transcribe(context.Background(), STTParams{InputFormat: InputFormatPCM}, []byte("pcm"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "Hello world" {
+		t.Errorf("expected 'Hello world', got %q", text)
+	}
+	if !mock.TranscribeWasCalled() {
+		t.Error("expected Transcribe to be recorded")
+	}
+
+	detailed, _ := mock.STT.TranscribeDetailed(context.Background(), STTParams{}, nil)
+	if !reflect.DeepEqual(detailed, results) {
+		t.Errorf("expected %+v, got %+v", results, detailed)
+	}
+
+	fromReader, err := mock.STT.TranscribeReader(context.Background(), STTParams{}, strings.NewReader("pcm"))
+	if err != nil || fromReader != "Hello world" {
+		t.Errorf("expected 'Hello world', got %q (err %v)", fromReader, err)
+	}
+}
+
+func TestMockClient_Voices(t *testing.T) {
+	mock := NewMockClient(WithMockVoices([]Voice{{UID: "v1", Name: "Emma"}}))
+	ctx := context.Background()
+
+	created, err := mock.Voices.Create(ctx, strings.NewReader("audio"), "sample.wav", VoiceCreateParams{Name: "Kent"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newName := "Kent Jr"
+	if _, err := mock.Voices.Update(ctx, *created.UID, VoiceUpdateParams{Name: &newName}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	voice, err := mock.Voices.Get(ctx, *created.UID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if voice.Name != newName || voice.UpdatedAt == nil {
+		t.Errorf("expected updated voice, got %+v", voice)
+	}
+
+	if err := mock.Voices.Delete(ctx, "v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voices, _ := mock.Voices.List(ctx, nil)
+	if len(voices) != 1 || voices[0].UID != *created.UID {
+		t.Errorf("expected only the created voice, got %+v", voices)
+	}
+
+	found, _ := mock.Voices.Search(ctx, "kent")
+	if len(found) != 1 || found[0].Name != newName {
+		t.Errorf("expected search to find the renamed voice, got %+v", found)
+	}
+
+	if _, err := mock.Voices.Get(ctx, "v1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected NotFoundError for deleted voice, got %v", err)
+	}
+	if _, err := mock.Voices.Create(ctx, strings.NewReader("audio"), "sample.wav", VoiceCreateParams{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ValidationError for missing name, got %v", err)
+	}
+
+	errs := mock.Voices.DeleteBatch(ctx, []string{*created.UID, "v1"})
+	if errs[0] != nil || !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("expected only the second delete to fail, got %v", errs)
+	}
+
+	fromURL, err := mock.Voices.CreateFromURL(ctx, "https://example.com/sample.wav", "sample.wav", VoiceCreateParams{Name: "Remote"})
+	if err != nil || fromURL.Voice == nil || fromURL.Voice.Name != "Remote" {
+		t.Errorf("expected a created voice, got %+v (err %v)", fromURL, err)
+	}
+
+	var methods []string
+	for _, call := range mock.Calls() {
+		methods = append(methods, call.Method)
+	}
+	want := []string{"Voices.Create", "Voices.Update", "Voices.Get", "Voices.Delete", "Voices.List", "Voices.Search", "Voices.Get", "Voices.Create", "Voices.Delete", "Voices.Delete", "Voices.CreateFromURL"}
+	if !reflect.DeepEqual(methods, want) {
+		t.Errorf("expected calls %v, got %v", want, methods)
+	}
+}
+
+func TestMockClient_Credits(t *testing.T) {
+	mock := NewMockClient(WithMockCredits(CreditsSummary{RemainingCredits: 42}))
+
+	credits, err := mock.Credits.Get(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credits.RemainingCredits != 42 {
+		t.Errorf("expected 42 credits, got %d", credits.RemainingCredits)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	summaries, errs := mock.Credits.Watch(ctx, time.Minute)
+	if summary := <-summaries; summary.RemainingCredits != 42 {
+		t.Errorf("expected 42 credits from Watch, got %d", summary.RemainingCredits)
+	}
+	cancel()
+	if err := <-errs; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMockClient_CreditsWatchInvalidInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{name: "zero", interval: 0},
+		{name: "negative", interval: -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMockClient()
+			summaries, errs := mock.Credits.Watch(context.Background(), tt.interval)
+			if err := <-errs; !errors.Is(err, ErrValidation) {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+			if _, ok := <-summaries; ok {
+				t.Error("expected no summary for an invalid interval")
+			}
+		})
+	}
+}
+
+func TestMockClient_WithMockError(t *testing.T) {
+	wantErr := &RateLimitError{Message: "slow down"}
+	mock := NewMockClient(WithMockError("Credits.Get", wantErr))
+
+	_, err := mock.Credits.Get(context.Background())
+	if !errors.Is(err, ErrRateLimit) {
+		t.Errorf("expected RateLimitError, got %v", err)
+	}
+	if !mock.WasCalled("Credits.Get") {
+		t.Error("expected failing call to be recorded")
+	}
+}
@@ -0,0 +1,101 @@
+// Package gradiumotel traces a gradium.Client with OpenTelemetry. It is a
+// separate module, imported as github.com/confiture-ai/gradium-sdk-go/otel,
+// so that applications without OpenTelemetry never depend on it.
+//
+// Example:
+//
+//	client, err := gradium.NewClient(
+//	    gradiumotel.WithTracer(otel.GetTracerProvider()),
+//	)
+package gradiumotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	gradium "github.com/confiture-ai/gradium-sdk-go"
+)
+
+// instrumentationName identifies the spans created by this package.
+const instrumentationName = "github.com/confiture-ai/gradium-sdk-go/otel"
+
+// WithTracer traces the client with tp. Every HTTP request made by the
+// Voices and Credits services goes through an otelhttp transport, and TTS
+// and STT streams get spans for the session, WaitReady and CollectText,
+// annotated with the voice, format, model, request ID and the number of
+// audio bytes transferred.
+func WithTracer(tp trace.TracerProvider) gradium.ClientOption {
+	transport := otelhttp.NewTransport(nextTransport{}, otelhttp.WithTracerProvider(tp))
+	middleware := gradium.WithMiddleware(func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		ctx := context.WithValue(req.Context(), nextKey{}, next)
+		return transport.RoundTrip(req.WithContext(ctx))
+	})
+	streams := gradium.WithStreamTracer(&streamTracer{tracer: tp.Tracer(instrumentationName)})
+
+	return func(c *gradium.Client) {
+		middleware(c)
+		streams(c)
+	}
+}
+
+// nextKey is the context key under which the middleware passes the next
+// RoundTripper through the shared otelhttp transport.
+type nextKey struct{}
+
+// nextTransport forwards a request to the RoundTripper stored in its
+// context, so one otelhttp transport can serve every middleware call.
+type nextTransport struct{}
+
+func (nextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return req.Context().Value(nextKey{}).(http.RoundTripper).RoundTrip(req)
+}
+
+// streamTracer implements gradium.StreamTracer with an OpenTelemetry tracer.
+type streamTracer struct {
+	tracer trace.Tracer
+}
+
+func (t *streamTracer) StartSpan(ctx context.Context, name string, attrs ...gradium.SpanAttribute) (context.Context, gradium.StreamSpan) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+	return ctx, &streamSpan{span: span}
+}
+
+type streamSpan struct {
+	span trace.Span
+}
+
+func (s *streamSpan) SetAttributes(attrs ...gradium.SpanAttribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+func (s *streamSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// convert maps SDK span attributes to OpenTelemetry ones.
+func convert(attrs []gradium.SpanAttribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, len(attrs))
+	for i, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs[i] = attribute.String(a.Key, v)
+		case int64:
+			kvs[i] = attribute.Int64(a.Key, v)
+		case int:
+			kvs[i] = attribute.Int(a.Key, v)
+		default:
+			kvs[i] = attribute.String(a.Key, fmt.Sprint(v))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,203 @@
+package gradiumotel
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	gradium "github.com/confiture-ai/gradium-sdk-go"
+)
+
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// newTracedClient returns a client for server that records spans in memory.
+func newTracedClient(t *testing.T, server *httptest.Server) (*gradium.Client, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	client, err := gradium.NewClient(
+		gradium.WithAPIKey("test-key"),
+		gradium.WithBaseURL(server.URL),
+		WithTracer(tp),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client, exporter
+}
+
+// findSpan returns the ended span called name, failing the test if absent.
+func findSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %q not recorded; got %v", name, exporter.GetSpans().Snapshots())
+	return tracetest.SpanStub{}
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestWithTracer_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(gradium.CreditsSummary{RemainingCredits: 10})
+	}))
+	defer server.Close()
+
+	client, exporter := newTracedClient(t, server)
+
+	if _, err := client.Credits.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 HTTP span, got %d", len(spans))
+	}
+	if got := attributes(spans[0])["http.response.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("expected status code attribute 200, got %d", got)
+	}
+}
+
+func TestWithTracer_TTSStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup map[string]interface{}
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		var msg map[string]interface{}
+		conn.ReadJSON(&msg)
+		conn.ReadJSON(&msg)
+		conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("12345"))})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	client, exporter := newTracedClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.TTS.Create(ctx, gradium.TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: gradium.FormatPCM,
+		Text:         "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(result.RawData) != "12345" {
+		t.Fatalf("unexpected audio %q", result.RawData)
+	}
+
+	findSpan(t, exporter, gradium.SpanTTSWaitReady)
+
+	audio := findSpan(t, exporter, gradium.SpanTTSAudio)
+	attrs := attributes(audio)
+	want := map[attribute.Key]attribute.Value{
+		gradium.AttrVoiceID:               attribute.StringValue("voice-123"),
+		gradium.AttrOutputFormat:          attribute.StringValue("pcm"),
+		gradium.AttrModelName:             attribute.StringValue(gradium.DefaultModelName),
+		gradium.AttrRequestID:             attribute.StringValue("req-123"),
+		gradium.AttrAudioBytesTransferred: attribute.Int64Value(5),
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value.Emit(), attrs[key].Emit())
+		}
+	}
+	if audio.Status.Code == codes.Error {
+		t.Errorf("expected successful span, got %v", audio.Status)
+	}
+}
+
+func TestWithTracer_STTStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup map[string]interface{}
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "stt-req", "sample_rate": 24000, "frame_size": 1920})
+		for {
+			var msg map[string]interface{}
+			if err := conn.ReadJSON(&msg); err != nil || msg["type"] == "end_of_stream" {
+				break
+			}
+		}
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "hello", "start_s": 0.0})
+		conn.WriteJSON(map[string]interface{}{"type": "error", "message": "boom", "code": 500})
+	}))
+	defer server.Close()
+
+	client, exporter := newTracedClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.STT.Stream(ctx, gradium.STTParams{InputFormat: gradium.InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if _, err := stream.WaitReady(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream.SendAudio(make([]byte, 3840))
+	stream.SendEndOfStream()
+	if _, err := stream.CollectText(ctx); err == nil {
+		t.Fatal("expected stream error")
+	}
+	<-stream.Done()
+
+	findSpan(t, exporter, gradium.SpanSTTWaitReady)
+
+	collect := findSpan(t, exporter, gradium.SpanSTTCollectText)
+	if collect.Status.Code != codes.Error {
+		t.Errorf("expected CollectText span to record the error, got %v", collect.Status)
+	}
+
+	session := findSpan(t, exporter, gradium.SpanSTTStream)
+	attrs := attributes(session)
+	if got := attrs[gradium.AttrRequestID].AsString(); got != "stt-req" {
+		t.Errorf("expected request_id 'stt-req', got %q", got)
+	}
+	if got := attrs[gradium.AttrAudioBytesTransferred].AsInt64(); got != 3840 {
+		t.Errorf("expected 3840 audio bytes, got %d", got)
+	}
+	if session.Status.Code != codes.Error {
+		t.Errorf("expected stream span to record the error, got %v", session.Status)
+	}
+}
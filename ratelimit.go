@@ -0,0 +1,142 @@
+package gradium
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit limits the client to rps requests per second with bursts of
+// up to burst, waiting for a slot before every HTTP request and WebSocket
+// dial. Retries count against the limit too. When the server answers 429
+// with a Retry-After header, every caller waits out that duration before its
+// next request. Clients derived with Client.WithAPIKey share the limiter.
+// An rps or burst of zero or less means no limit; 429 back-off still applies.
+//
+// Example:
+//
+//	client, err := gradium.NewClient(gradium.WithRateLimit(10, 5))
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(rps, burst, false)
+	}
+}
+
+// WithRateLimitNonBlocking is like WithRateLimit but fails requests with
+// ErrRateLimited instead of waiting when no slot is free or the client is
+// backing off after a 429.
+func WithRateLimitNonBlocking(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(rps, burst, true)
+	}
+}
+
+// rateLimiter is a token bucket shared by every request of a client, paused
+// while the server asks clients to back off.
+type rateLimiter struct {
+	limiter     *rate.Limiter
+	nonBlocking bool
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newRateLimiter(rps float64, burst int, nonBlocking bool) *rateLimiter {
+	limit := rate.Limit(rps)
+	if rps <= 0 || burst <= 0 {
+		limit = rate.Inf
+	}
+	return &rateLimiter{
+		limiter:     rate.NewLimiter(limit, burst),
+		nonBlocking: nonBlocking,
+	}
+}
+
+// wait blocks until a request may be sent, or returns ErrRateLimited if the
+// limiter is non-blocking and none may be sent now. A nil limiter never
+// blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+	if pause > 0 {
+		if l.nonBlocking {
+			return ErrRateLimited
+		}
+		if err := sleepContext(ctx, pause); err != nil {
+			return err
+		}
+	}
+
+	if l.nonBlocking {
+		if !l.limiter.Allow() {
+			return ErrRateLimited
+		}
+		return nil
+	}
+
+	r := l.limiter.Reserve()
+	if err := sleepContext(ctx, r.Delay()); err != nil {
+		r.Cancel()
+		return err
+	}
+	return nil
+}
+
+// observe pauses the limiter for the Retry-After duration of a 429
+// response. A nil limiter or nil response is ignored.
+func (l *rateLimiter) observe(resp *http.Response) {
+	if l == nil || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return
+	}
+
+	until := time.Now().Add(time.Duration(seconds) * time.Second)
+	l.mu.Lock()
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.mu.Unlock()
+}
+
+// sleepContext waits for d, returning ctx.Err() if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport is an http.RoundTripper that waits on the client's
+// rate limiter before each request.
+type rateLimitTransport struct {
+	limiter *rateLimiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	t.limiter.observe(resp)
+	return resp, err
+}
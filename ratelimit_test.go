@@ -0,0 +1,162 @@
+package gradium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: 100})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		opt          ClientOption
+		calls        int
+		minElapsed   time.Duration
+		wantLimited  int
+		wantRequests int32
+	}{
+		{
+			name:         "blocking waits for slots",
+			opt:          WithRateLimit(20, 1),
+			calls:        4,
+			minElapsed:   120 * time.Millisecond,
+			wantRequests: 4,
+		},
+		{
+			name:         "non-blocking fails fast",
+			opt:          WithRateLimitNonBlocking(0.1, 2),
+			calls:        4,
+			wantLimited:  2,
+			wantRequests: 2,
+		},
+		{
+			name:         "zero rps is unlimited",
+			opt:          WithRateLimit(0, 5),
+			calls:        4,
+			wantRequests: 4,
+		},
+		{
+			name:         "zero burst is unlimited",
+			opt:          WithRateLimitNonBlocking(1, 0),
+			calls:        4,
+			wantRequests: 4,
+		},
+		{
+			name:         "negative rps is unlimited",
+			opt:          WithRateLimitNonBlocking(-1, -1),
+			calls:        4,
+			wantRequests: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), tt.opt)
+
+			start := time.Now()
+			limited := 0
+			for i := 0; i < tt.calls; i++ {
+				_, err := client.Credits.Get(context.Background())
+				switch {
+				case errors.Is(err, ErrRateLimited):
+					limited++
+				case err != nil:
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if elapsed := time.Since(start); elapsed < tt.minElapsed {
+				t.Errorf("expected calls to take at least %v, took %v", tt.minElapsed, elapsed)
+			}
+			if limited != tt.wantLimited {
+				t.Errorf("expected %d rate-limited calls, got %d", tt.wantLimited, limited)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("expected %d requests to reach the server, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestWithRateLimitRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: 100})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRateLimitNonBlocking(100, 10))
+	other := client.WithAPIKey("other-key")
+
+	_, err := client.Credits.Get(context.Background())
+	if !errors.Is(err, ErrRateLimit) {
+		t.Fatalf("expected server rate limit error, got %v", err)
+	}
+
+	// The 429 pauses the shared limiter, so the next caller backs off too
+	_, err = other.Credits.Get(context.Background())
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited while backing off, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request to reach the server, got %d", got)
+	}
+}
+
+func TestWithRateLimitWebSocketDial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRateLimitNonBlocking(0.1, 1))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if _, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123"}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited for the second dial, got %v", err)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := newRateLimiter(0.001, 1, false)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package gradium
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Default values used for zero fields of a RetryPolicy.
+const (
+	defaultRetryMaxAttempts     = 3
+	defaultRetryInitialInterval = 500 * time.Millisecond
+	defaultRetryMaxInterval     = 30 * time.Second
+	defaultRetryMultiplier      = 2.0
+)
+
+// RetryPolicy controls how failed requests are retried. Zero fields take
+// their defaults: 3 attempts, a 500ms initial interval doubling up to 30s,
+// and DefaultRetryableError.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialInterval is the wait before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between attempts.
+	MaxInterval time.Duration
+	// Multiplier grows the interval after each retry.
+	Multiplier float64
+	// RetryableErrors reports whether a failed attempt should be retried.
+	RetryableErrors func(error) bool
+}
+
+// WithRetry retries HTTP requests and WebSocket dials that fail with a
+// retryable error, waiting with exponential backoff and jitter between
+// attempts. A RateLimitError with RetryAfter set waits that long instead.
+// Cancelling the request context stops retrying immediately.
+//
+// VoicesService.Create only retries when the audio reader implements
+// io.Seeker, since the upload must be replayed from the start.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if policy.InitialInterval <= 0 {
+			policy.InitialInterval = defaultRetryInitialInterval
+		}
+		if policy.MaxInterval <= 0 {
+			policy.MaxInterval = defaultRetryMaxInterval
+		}
+		if policy.Multiplier < 1 {
+			policy.Multiplier = defaultRetryMultiplier
+		}
+		if policy.RetryableErrors == nil {
+			policy.RetryableErrors = DefaultRetryableError
+		}
+		c.retryPolicy = &policy
+	}
+}
+
+// DefaultRetryableError reports whether err is a RateLimitError or an
+// InternalServerError. Authentication, validation and not-found errors are
+// never retried.
+func DefaultRetryableError(err error) bool {
+	var rateLimitErr *RateLimitError
+	var internalErr *InternalServerError
+	return errors.As(err, &rateLimitErr) || errors.As(err, &internalErr)
+}
+
+// retry calls fn until it succeeds, returns a non-retryable error, or the
+// client's retry policy runs out of attempts. Without a policy fn is called
+// once.
+func retry[T any](ctx context.Context, c *Client, fn func() (T, error)) (T, error) {
+	policy := c.retryPolicy
+	if policy == nil {
+		return fn()
+	}
+
+	interval := policy.InitialInterval
+	for attempt := 1; ; attempt++ {
+		result, err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.RetryableErrors(err) {
+			return result, err
+		}
+
+		delay := retryDelay(interval, err)
+		c.log().Warn("retrying request", "attempt", attempt, "delay", delay, logKeyError, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, ctx.Err()
+		}
+
+		interval = min(time.Duration(float64(interval)*policy.Multiplier), policy.MaxInterval)
+	}
+}
+
+// retryDelay returns how long to wait before retrying after err: the
+// server's Retry-After for rate limits, or a random duration between half
+// and all of interval otherwise.
+func retryDelay(interval time.Duration, err error) time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+		return time.Duration(rateLimitErr.RetryAfter) * time.Second
+	}
+	half := interval / 2
+	return half + rand.N(interval-half+1)
+}
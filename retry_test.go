@@ -0,0 +1,249 @@
+package gradium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry retries quickly so tests don't wait on backoff.
+var fastRetry = RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		failStatus   int
+		policy       *RetryPolicy
+		wantAttempts int32
+		wantErrType  string
+	}{
+		{
+			name:         "no policy",
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+			wantErrType:  "*gradium.InternalServerError",
+		},
+		{
+			name:         "server error then success",
+			failures:     2,
+			failStatus:   http.StatusServiceUnavailable,
+			policy:       &fastRetry,
+			wantAttempts: 3,
+		},
+		{
+			name:         "rate limit then success",
+			failures:     1,
+			failStatus:   http.StatusTooManyRequests,
+			policy:       &fastRetry,
+			wantAttempts: 2,
+		},
+		{
+			name:         "attempts exhausted",
+			failures:     5,
+			failStatus:   http.StatusInternalServerError,
+			policy:       &fastRetry,
+			wantAttempts: 3,
+			wantErrType:  "*gradium.InternalServerError",
+		},
+		{
+			name:         "authentication error not retried",
+			failures:     5,
+			failStatus:   http.StatusUnauthorized,
+			policy:       &fastRetry,
+			wantAttempts: 1,
+			wantErrType:  "*gradium.AuthenticationError",
+		},
+		{
+			name:         "not found not retried",
+			failures:     5,
+			failStatus:   http.StatusNotFound,
+			policy:       &fastRetry,
+			wantAttempts: 1,
+			wantErrType:  "*gradium.NotFoundError",
+		},
+		{
+			name:         "validation error not retried",
+			failures:     5,
+			failStatus:   http.StatusUnprocessableEntity,
+			policy:       &fastRetry,
+			wantAttempts: 1,
+			wantErrType:  "*gradium.ValidationError",
+		},
+		{
+			name:       "custom retryable errors",
+			failures:   1,
+			failStatus: http.StatusNotFound,
+			policy: &RetryPolicy{
+				MaxAttempts:     3,
+				InitialInterval: time.Millisecond,
+				RetryableErrors: func(err error) bool { return errors.Is(err, ErrNotFound) },
+			},
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if attempts.Add(1) <= int32(tt.failures) {
+					w.WriteHeader(tt.failStatus)
+					json.NewEncoder(w).Encode(map[string]string{"detail": "failed"})
+					return
+				}
+				json.NewEncoder(w).Encode(CreditsSummary{RemainingCredits: 100})
+			}))
+			defer server.Close()
+
+			opts := []ClientOption{WithAPIKey("test-key"), WithBaseURL(server.URL)}
+			if tt.policy != nil {
+				opts = append(opts, WithRetry(*tt.policy))
+			}
+			client, _ := NewClient(opts...)
+
+			_, err := client.Credits.Get(context.Background())
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+			if tt.wantErrType == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if got := getErrorTypeName(err); got != tt.wantErrType {
+				t.Errorf("expected error type %s, got %s (%v)", tt.wantErrType, got, err)
+			}
+		})
+	}
+}
+
+func TestWithRetryContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithRetry(RetryPolicy{MaxAttempts: 5, InitialInterval: time.Hour}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.Credits.Get(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retry loop not aborted by cancellation, took %v", elapsed)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if got := retryDelay(time.Second, &RateLimitError{RetryAfter: 7}); got != 7*time.Second {
+		t.Errorf("expected Retry-After of 7s, got %v", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		got := retryDelay(time.Second, &InternalServerError{Status: 503})
+		if got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("expected jittered delay in [500ms, 1s], got %v", got)
+		}
+	}
+}
+
+func TestWithRetryWebSocketDial(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetry(fastRetry))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if err := stream.WaitReady(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 dial attempts, got %d", got)
+	}
+}
+
+func TestWithRetryVoiceCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		audio        io.Reader
+		wantAttempts int32
+	}{
+		{name: "seekable audio is replayed", audio: strings.NewReader("audio bytes"), wantAttempts: 2},
+		{name: "plain reader is not retried", audio: io.MultiReader(strings.NewReader("audio bytes")), wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				file, _, err := r.FormFile("audio_file")
+				if err != nil {
+					t.Errorf("failed to get audio file: %v", err)
+					return
+				}
+				defer file.Close()
+				if content, _ := io.ReadAll(file); string(content) != "audio bytes" {
+					t.Errorf("expected full audio on every attempt, got %q", content)
+				}
+
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(VoiceCreateResponse{UID: stringPtr("voice-123")})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRetry(fastRetry))
+
+			_, _ = client.Voices.Create(context.Background(), tt.audio, "sample.wav", VoiceCreateParams{Name: "Voice"})
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+}
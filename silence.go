@@ -0,0 +1,83 @@
+package gradium
+
+import (
+	"context"
+	"time"
+)
+
+// AutoEndOnSilence sends end-of-stream once the speaker has been silent for
+// holdDuration: every VAD step over that span must report an inactivity
+// probability above threshold, with no text result arriving in between.
+// Inactivity is read from the last (longest-horizon) prediction of each step
+// and the span is measured in audio time, from the steps' TotalDurationS.
+//
+// The watch runs in a goroutine until end-of-stream is sent, ctx is done or
+// the stream ends. It sees VAD steps and text without consuming them from
+// VAD, Text or All. Only one watch may be active per stream.
+//
+// Example:
+//
+//	stream.WaitReady(ctx)
+//	stream.AutoEndOnSilence(ctx, 0.8, 700*time.Millisecond)
+//	go sendMicrophone(stream)
+//	text, err := stream.CollectText(ctx)
+func (s *STTStream) AutoEndOnSilence(ctx context.Context, threshold float64, holdDuration time.Duration) error {
+	if !(threshold >= 0 && threshold <= 1) {
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "threshold must be between 0 and 1", Loc: []interface{}{"threshold"}}}}
+	}
+	if holdDuration < 0 {
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "hold duration must not be negative", Loc: []interface{}{"hold_duration"}}}}
+	}
+
+	events := make(chan interface{}, cap(s.vadCh))
+	if !s.silenceTap.CompareAndSwap(nil, &events) {
+		return &Error{Message: "AutoEndOnSilence is already active on this stream"}
+	}
+
+	go func() {
+		defer s.silenceTap.Store(nil)
+
+		hold := holdDuration.Seconds()
+		silentSince := -1.0 // audio time at which silence began, or -1
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.done:
+				return
+			case event := <-events:
+				step, ok := event.(STTStepResult)
+				if !ok {
+					// A text result means the speaker is not done.
+					silentSince = -1
+					continue
+				}
+				if len(step.VAD) == 0 || step.VAD[len(step.VAD)-1].InactivityProb <= threshold {
+					silentSince = -1
+					continue
+				}
+				if silentSince < 0 {
+					silentSince = step.TotalDurationS
+				}
+				if step.TotalDurationS-silentSince >= hold {
+					_ = s.SendEndOfStream()
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// tapSilence passes a VAD step or text result to an active AutoEndOnSilence
+// watch without blocking.
+func (s *STTStream) tapSilence(msg interface{}) {
+	tap := s.silenceTap.Load()
+	if tap == nil {
+		return
+	}
+	select {
+	case *tap <- msg:
+	default:
+	}
+}
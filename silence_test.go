@@ -0,0 +1,170 @@
+package gradium
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// vadStep returns a step message whose last prediction has inactivity prob
+// at audio time totalS.
+func vadStep(totalS, prob float64) map[string]interface{} {
+	return map[string]interface{}{
+		"type":             "step",
+		"vad":              []map[string]float64{{"horizon_s": 0.5, "inactivity_prob": 0.0}, {"horizon_s": 2, "inactivity_prob": prob}},
+		"step_duration_s":  0.1,
+		"total_duration_s": totalS,
+	}
+}
+
+// vadSteps returns steps every 100ms from fromS up to but excluding toS.
+func vadSteps(fromS, toS, prob float64) []map[string]interface{} {
+	var steps []map[string]interface{}
+	for t := fromS; t < toS-1e-9; t += 0.1 {
+		steps = append(steps, vadStep(t, prob))
+	}
+	return steps
+}
+
+func concatEvents(groups ...[]map[string]interface{}) []map[string]interface{} {
+	var events []map[string]interface{}
+	for _, g := range groups {
+		events = append(events, g...)
+	}
+	return events
+}
+
+func TestSTTStream_AutoEndOnSilence(t *testing.T) {
+	text := []map[string]interface{}{{"type": "text", "text": "and", "start_s": 0.4}}
+
+	tests := []struct {
+		name    string
+		events  []map[string]interface{}
+		wantEnd bool
+	}{
+		{
+			name:    "sustained silence",
+			events:  vadSteps(0, 1, 0.9),
+			wantEnd: true,
+		},
+		{
+			name:   "silence shorter than hold",
+			events: concatEvents(vadSteps(0, 0.4, 0.9), vadSteps(0.4, 0.5, 0.2), vadSteps(0.5, 0.9, 0.9)),
+		},
+		{
+			name:   "text resets the hold",
+			events: concatEvents(vadSteps(0, 0.4, 0.9), text, vadSteps(0.4, 0.8, 0.9)),
+		},
+		{
+			name:   "below threshold",
+			events: vadSteps(0, 1, 0.7),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endCh := make(chan bool, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup map[string]interface{}
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+				conn.ReadMessage() // the client's first audio chunk, sent once the watch is active
+				for _, event := range tt.events {
+					conn.WriteJSON(event)
+				}
+
+				conn.SetReadDeadline(time.Now().Add(300 * time.Millisecond))
+				var msg wsMessage
+				endCh <- conn.ReadJSON(&msg) == nil && msg.Type == "end_of_stream"
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := stream.WaitReady(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := stream.AutoEndOnSilence(ctx, 0.8, 500*time.Millisecond); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			stream.SendAudio(make([]byte, 3840))
+
+			if got := <-endCh; got != tt.wantEnd {
+				t.Errorf("expected end of stream sent %v, got %v", tt.wantEnd, got)
+			}
+			// The caller still receives every VAD step.
+			want := countSteps(tt.events)
+			for deadline := time.Now().Add(time.Second); len(stream.VAD()) < want && time.Now().Before(deadline); {
+				time.Sleep(5 * time.Millisecond)
+			}
+			if got := len(stream.VAD()); got != want {
+				t.Errorf("expected %d VAD steps on VAD(), got %d", want, got)
+			}
+		})
+	}
+}
+
+func countSteps(events []map[string]interface{}) int {
+	n := 0
+	for _, e := range events {
+		if e["type"] == "step" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSTTStream_AutoEndOnSilenceErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	ctx := context.Background()
+	if err := stream.AutoEndOnSilence(ctx, 1.5, time.Second); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ValidationError for threshold, got %v", err)
+	}
+	if err := stream.AutoEndOnSilence(ctx, 0.8, -time.Second); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ValidationError for hold duration, got %v", err)
+	}
+	if err := stream.AutoEndOnSilence(ctx, 0.8, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stream.AutoEndOnSilence(ctx, 0.8, time.Second); err == nil {
+		t.Error("expected an error for a second active watch")
+	}
+}
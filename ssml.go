@@ -0,0 +1,25 @@
+package gradium
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ValidateSSML returns a ValidationError carrying the parse error if text is
+// not well-formed XML. Fragments without a root element, such as
+// `Hello <break time="1s"/> world`, are accepted; only the markup is checked,
+// not which SSML elements are used.
+func ValidateSSML(text string) error {
+	dec := xml.NewDecoder(strings.NewReader(text))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "invalid SSML: " + err.Error(), Loc: []interface{}{"text"}}}}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package gradium
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateSSML(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "plain text", text: "Hello, world!"},
+		{name: "fragment", text: `Hello <break time="1s"/> world`},
+		{name: "document", text: `<speak>I have <say-as interpret-as="cardinal">42</say-as> <emphasis>apples</emphasis>.</speak>`},
+		{name: "unclosed element", text: "<speak>Hello", wantErr: true},
+		{name: "mismatched element", text: "<speak><emphasis>Hello</speak></emphasis>", wantErr: true},
+		{name: "bare ampersand", text: "<speak>Salt & pepper</speak>", wantErr: true},
+		{name: "unquoted attribute", text: "<break time=1s/>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSSML(tt.text)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "invalid SSML") {
+				t.Errorf("expected parse detail in error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestTTSService_CreateSSML(t *testing.T) {
+	ssml := `<speak>Hello there. <break time="500ms"/> How are you today?</speak>`
+
+	tests := []struct {
+		name      string
+		text      string
+		wantErr   bool
+		wantConns int32
+		want      []string
+	}{
+		{name: "sent unsplit", text: ssml, wantConns: 1, want: []string{ssml}},
+		{name: "malformed rejected before dialing", text: "<speak>Hello", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conns atomic.Int32
+			var received []string
+			var mu sync.Mutex
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				conns.Add(1)
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				for {
+					var textMsg ttsTextMessage
+					if err := conn.ReadJSON(&textMsg); err != nil {
+						return
+					}
+					if textMsg.Type == "end_of_stream" {
+						break
+					}
+					mu.Lock()
+					received = append(received, textMsg.Text)
+					mu.Unlock()
+				}
+				conn.WriteJSON(map[string]string{
+					"type":  "audio",
+					"audio": base64.StdEncoding.EncodeToString([]byte("audio")),
+				})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := client.TTS.Create(ctx, TTSParams{
+				VoiceID:       "voice-123",
+				OutputFormat:  FormatPCM,
+				Text:          tt.text,
+				IsSSML:        true,
+				MaxChunkBytes: 20,
+			})
+			if tt.wantErr {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected ValidationError, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			if got := conns.Load(); got != tt.wantConns {
+				t.Errorf("expected %d connections, got %d", tt.wantConns, got)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if strings.Join(received, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("expected %q, got %q", tt.want, received)
+			}
+		})
+	}
+}
+
+func TestTTSStream_SendTextSSML(t *testing.T) {
+	received := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		for {
+			var textMsg ttsTextMessage
+			if err := conn.ReadJSON(&textMsg); err != nil || textMsg.Type == "end_of_stream" {
+				close(received)
+				return
+			}
+			received <- textMsg.Text
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM, IsSSML: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if err := stream.SendText("<emphasis>Hi"); err == nil {
+		t.Error("expected error for malformed SSML")
+	}
+	if err := stream.SendText("<emphasis>Hi</emphasis>"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream.SendEndOfStream()
+
+	var got []string
+	for text := range received {
+		got = append(got, text)
+	}
+	if len(got) != 1 || got[0] != "<emphasis>Hi</emphasis>" {
+		t.Errorf("expected only the well-formed SSML to be sent, got %q", got)
+	}
+}
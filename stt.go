@@ -4,9 +4,16 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -16,31 +23,88 @@ const (
 	msgTypeReady       = "ready"
 	msgTypeError       = "error"
 	msgTypeEndOfStream = "end_of_stream"
-	modelNameDefault   = "default"
 )
 
 // STTService handles speech-to-text operations.
 type STTService struct {
-	client *Client
+	client  *Client
+	timeout time.Duration
 }
 
 // STTStream handles streaming STT responses.
 type STTStream struct {
 	conn        *websocket.Conn
+	writeMu     sync.Mutex // serialises writes to conn and guards pending
 	readyInfo   *STTReadyInfo
 	readyInfoMu sync.RWMutex
+	textStreams []chan STTTextResult
 	ready       chan struct{}
 	done        chan struct{}
+	doneErr     chan error
 	err         error
 	errMu       sync.RWMutex
 	textCh      chan STTTextResult
 	vadCh       chan STTStepResult
 	endTextCh   chan STTEndTextResult
 	allMsgCh    chan interface{}
-	closeOnce   sync.Once
+	discardedCh chan STTTextResult
+	unknownCh   chan STTUnknownEvent
+	allIn       chan interface{}
+	// allRequested is set by the first call to All. Until then forwardAll
+	// keeps only what fits in allMsgCh's buffer.
+	allRequested atomic.Bool
+	closed       chan struct{}
+	closeOnce    sync.Once
+	tracer       StreamTracer
+	span         StreamSpan
+	logger       *slog.Logger
+
+	// confidenceThreshold is STTParams.ConfidenceThreshold.
+	confidenceThreshold *float64
+	// validateAudio is STTParams.ValidateAudio.
+	validateAudio bool
+	// rawPCM is set when STTParams.InputFormat is InputFormatPCM. Only raw
+	// PCM is split into frames and padded; other formats are containers or
+	// bitstreams that zero bytes would corrupt.
+	rawPCM bool
+	// pending holds audio passed to SendAudio that does not yet fill a
+	// frame. Flush sends it.
+	pending []byte
+
+	// firstAudioAt is the Unix time in nanoseconds of the first non-empty
+	// SendAudio call, or 0 if none has been made yet.
+	firstAudioAt atomic.Int64
+	// silenceTap receives VAD steps and text results while AutoEndOnSilence
+	// is active, and is nil otherwise.
+	silenceTap atomic.Pointer[chan interface{}]
+	// firstTextAt is the Unix time in nanoseconds at which the first text
+	// result was received, or 0 if none has arrived yet.
+	firstTextAt atomic.Int64
+	// audioBytes counts the audio bytes sent with SendAudio.
+	audioBytes atomic.Int64
+	// dropped counts results discarded because Text, DiscardedText, VAD or
+	// EndText was full.
+	dropped atomic.Int64
+}
+
+// STTStreamOption configures an STTStream at creation time.
+type STTStreamOption func(*sttStreamConfig)
+
+type sttStreamConfig struct {
+	unknownBuf int
+}
+
+// WithSTTUnknownBuffer sets the capacity of the stream's Unknown channel.
+// The default is 10.
+func WithSTTUnknownBuffer(n int) STTStreamOption {
+	return func(c *sttStreamConfig) {
+		c.unknownBuf = n
+	}
 }
 
 // Stream creates a streaming STT connection.
+// If ctx is cancelled or expires while dialing, ctx.Err() is returned instead
+// of a ConnectionError.
 //
 // Example:
 //
@@ -58,51 +122,83 @@ type STTStream struct {
 //	for text := range stream.Text() {
 //	    fmt.Printf("Transcription: %s\n", text.Text)
 //	}
-func (s *STTService) Stream(ctx context.Context, params STTParams) (*STTStream, error) {
-	wsURL := s.client.wsURL + "/stt"
+func (s *STTService) Stream(ctx context.Context, params STTParams, opts ...STTStreamOption) (*STTStream, error) {
+	config := sttStreamConfig{unknownBuf: unknownChannelSize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if err := validateHotwords(params.Hotwords); err != nil {
+		return nil, err
+	}
+	if params.Language != nil && params.AutoDetectLanguage {
+		return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "language and auto_detect_language are mutually exclusive", Loc: []interface{}{"language"}}}}
+	}
+
+	modelName := resolveModelName(params.ModelName, s.client.sttModel)
 
-	header := http.Header{}
-	header.Set("x-api-key", s.client.apiKey)
+	tracer := s.client.tracer()
+	ctx, span := tracer.StartSpan(ctx, SpanSTTStream, SpanAttribute{Key: AttrModelName, Value: modelName})
 
-	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	conn, _, err := s.client.dialWebSocket(ctx, serviceSTT, "/stt")
 	if err != nil {
-		return nil, &ConnectionError{Message: "failed to connect to STT WebSocket: " + err.Error()}
+		span.End(err)
+		return nil, err
 	}
 
+	sizes := s.client.sttChannelSizes
 	stream := &STTStream{
 		conn:      conn,
 		ready:     make(chan struct{}),
 		done:      make(chan struct{}),
-		textCh:    make(chan STTTextResult, 100),
-		vadCh:     make(chan STTStepResult, 100),
-		endTextCh: make(chan STTEndTextResult, 10),
-		allMsgCh:  make(chan interface{}, 100),
-	}
+		doneErr:   make(chan error, 1),
+		textCh:    make(chan STTTextResult, sizes.text),
+		vadCh:     make(chan STTStepResult, sizes.vad),
+		endTextCh: make(chan STTEndTextResult, sizes.endText),
+		allMsgCh:  make(chan interface{}, sizes.all),
+		allIn:     make(chan interface{}),
+		closed:    make(chan struct{}),
+		tracer:    tracer,
+		span:      span,
+		logger:    s.client.log().With(logKeyService, serviceSTT),
 
-	// Send setup message
-	modelName := params.ModelName
-	if modelName == "" {
-		modelName = modelNameDefault
+		discardedCh:         make(chan STTTextResult, sizes.text),
+		unknownCh:           make(chan STTUnknownEvent, max(config.unknownBuf, 0)),
+		confidenceThreshold: params.ConfidenceThreshold,
+		validateAudio:       params.ValidateAudio,
+		rawPCM:              params.InputFormat == InputFormatPCM,
 	}
 
+	// Send setup message
 	setupMsg := sttSetupMessage{
 		Type:        "setup",
 		InputFormat: params.InputFormat,
 		ModelName:   modelName,
+
+		RequestWordTimestamps: params.RequestWordTimestamps,
+		Diarization:           params.Diarization,
+		ConfidenceThreshold:   params.ConfidenceThreshold,
+		Hotwords:              params.Hotwords,
+		Language:              params.Language,
+		AutoDetectLanguage:    params.AutoDetectLanguage,
 	}
 
 	if err := conn.WriteJSON(setupMsg); err != nil {
 		_ = conn.Close()
-		return nil, &WebSocketError{Message: "failed to send setup message: " + err.Error()}
+		err = &WebSocketError{Message: "failed to send setup message: " + err.Error()}
+		span.End(err)
+		return nil, err
 	}
 
-	// Start message handler
+	// Start message handler and All() fan-out
+	go stream.forwardAll()
 	go stream.handleMessages()
 
 	return stream, nil
 }
 
-// Transcribe transcribes complete audio data.
+// Transcribe transcribes complete audio data and returns the text segments
+// joined with spaces. Use TranscribeDetailed to keep per-segment timestamps.
 //
 // Example:
 //
@@ -111,41 +207,307 @@ func (s *STTService) Stream(ctx context.Context, params STTParams) (*STTStream,
 //	    InputFormat: gradium.InputFormatWAV,
 //	}, audioData)
 func (s *STTService) Transcribe(ctx context.Context, params STTParams, audio []byte) (string, error) {
-	stream, err := s.Stream(ctx, params)
+	results, err := s.TranscribeDetailed(ctx, params, audio)
 	if err != nil {
 		return "", err
 	}
+
+	return joinText(results), nil
+}
+
+// TranscribeDetailed transcribes complete audio data and returns the raw text
+// segments with their timestamps.
+//
+// Example:
+//
+//	segments, err := client.STT.TranscribeDetailed(ctx, gradium.STTParams{
+//	    InputFormat: gradium.InputFormatWAV,
+//	}, audioData)
+//	for _, seg := range segments {
+//	    fmt.Printf("[%.2fs] %s\n", seg.StartS, seg.Text)
+//	}
+func (s *STTService) TranscribeDetailed(ctx context.Context, params STTParams, audio []byte) ([]STTTextResult, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	stream, err := s.Stream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
 	defer func() { _ = stream.Close() }()
 
 	if _, err := stream.WaitReady(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := stream.sendAllAudio(audio); err != nil {
+		return nil, err
+	}
+
+	return stream.collectTextResults(ctx)
+}
+
+// TranscribeReader transcribes audio read from r and returns the text
+// segments joined with spaces. Audio is read and sent one frame
+// (FrameSize samples, from the ready info) at a time, so memory use does not
+// grow with the length of the input; end of stream is sent when r returns
+// io.EOF.
+//
+// Example:
+//
+//	f, _ := os.Open("audio.pcm")
+//	defer f.Close()
+//	text, err := client.STT.TranscribeReader(ctx, gradium.STTParams{
+//	    InputFormat: gradium.InputFormatPCM,
+//	}, f)
+func (s *STTService) TranscribeReader(ctx context.Context, params STTParams, r io.Reader) (string, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	stream, err := s.Stream(ctx, params)
+	if err != nil {
 		return "", err
 	}
+	defer func() { _ = stream.Close() }()
+
+	// Results are collected while audio is still being sent, so long inputs
+	// never fill the text channel. A send failure ends the stream with that
+	// error, which collectTextResults then returns.
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		stream.pumpReader(ctx, r)
+	}()
+
+	results, err := stream.collectTextResults(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Don't return while r may still be read from.
+	select {
+	case <-sent:
+	case <-ctx.Done():
+	}
+
+	return joinText(results), nil
+}
+
+// StreamReader starts a stream and sends the audio read from r in the
+// background, one frame at a time, followed by end of stream when r returns
+// io.EOF. It returns as soon as the stream is open so that Text, VAD and the
+// other channels can be consumed while audio is still being read. If reading
+// r or sending fails, the stream is closed and the error is reported on
+// DoneErr. Cancelling ctx stops the reader loop.
+//
+// Example:
+//
+//	stream, err := client.STT.StreamReader(ctx, gradium.STTParams{
+//	    InputFormat: gradium.InputFormatPCM,
+//	}, micReader)
+//	defer stream.Close()
+//
+//	for text := range stream.Text() {
+//	    fmt.Println(text.Text)
+//	}
+func (s *STTService) StreamReader(ctx context.Context, params STTParams, r io.Reader) (*STTStream, error) {
+	stream, err := s.Stream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	go stream.pumpReader(ctx, r)
+
+	return stream, nil
+}
+
+// VADOnly runs voice activity detection over complete audio data and returns
+// every step result, without waiting for any transcription.
+//
+// Example:
+//
+//	steps, err := client.STT.VADOnly(ctx, gradium.STTParams{
+//	    InputFormat: gradium.InputFormatPCM,
+//	}, audioData)
+func (s *STTService) VADOnly(ctx context.Context, params STTParams, audio []byte) ([]STTStepResult, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	stream, err := s.Stream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	if _, err := stream.WaitReady(ctx); err != nil {
+		return nil, err
+	}
 
-	// Send audio in chunks (1920 samples = 80ms at 24kHz, 2 bytes per sample)
-	chunkSize := 1920 * 2
+	// Collect steps from All rather than VAD: VAD drops steps once its
+	// buffer is full, while All delivers every message.
+	stepsCh := make(chan []STTStepResult, 1)
+	go func() {
+		var steps []STTStepResult
+		for msg := range stream.All() {
+			if step, ok := msg.(STTStepResult); ok {
+				steps = append(steps, step)
+			}
+		}
+		stepsCh <- steps
+	}()
+
+	if err := stream.sendAllAudio(audio); err != nil {
+		return nil, err
+	}
+
+	select {
+	case steps := <-stepsCh:
+		if err := stream.getError(); err != nil {
+			return nil, err
+		}
+		return steps, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// handshakeError builds a ConnectionError for a failed WebSocket dial. When
+// the server answered without upgrading, the message includes the HTTP status,
+// content type, missing upgrade headers and the start of the response body.
+// A 429 answer yields a RateLimitError and a 5xx answer an
+// InternalServerError with the same message.
+func handshakeError(service string, resp *http.Response, err error) error {
+	msg := "failed to connect to " + service + " WebSocket: " + err.Error()
+	if resp == nil {
+		return &ConnectionError{Message: msg}
+	}
+
+	msg += fmt.Sprintf(" (status %d", resp.StatusCode)
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		msg += ", content-type " + contentType
+	}
+
+	var missing []string
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		missing = append(missing, "Upgrade")
+	}
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Connection")), "upgrade") {
+		missing = append(missing, "Connection")
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") == "" {
+		missing = append(missing, "Sec-WebSocket-Accept")
+	}
+	if len(missing) > 0 {
+		msg += ", missing headers " + strings.Join(missing, ", ")
+	}
+	msg += ")"
+
+	if resp.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if len(body) > 0 {
+			msg += ": " + strings.TrimSpace(string(body))
+		}
+	}
+
+	// Rate limits and server errors keep their own types so they can be
+	// retried like the equivalent HTTP responses.
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &RateLimitError{Message: msg, RetryAfter: retryAfter}
+	case resp.StatusCode >= 500:
+		return &InternalServerError{Status: resp.StatusCode, Message: msg}
+	}
+
+	return &ConnectionError{Message: msg}
+}
+
+// Audio framing constants for 16-bit mono PCM.
+const (
+	bytesPerSample    = 2
+	defaultSampleRate = 24000
+	defaultFrameSize  = 1920 // 80ms at 24kHz
+)
+
+// MaxAudioChunkBytes is the largest chunk STTStream.SendAudio accepts when
+// STTParams.ValidateAudio is set: 1 MiB, about 21 seconds of 24kHz 16-bit
+// mono PCM.
+const MaxAudioChunkBytes = 1 << 20
+
+// sendAllAudio sends audio in chunks of one frame (FrameSize samples, as
+// reported in the ready message) followed by an end-of-stream message.
+func (s *STTStream) sendAllAudio(audio []byte) error {
+	chunkSize := s.readyInfoOrDefault().FrameSize * bytesPerSample
 	for i := 0; i < len(audio); i += chunkSize {
 		end := i + chunkSize
 		if end > len(audio) {
 			end = len(audio)
 		}
-		if err := stream.SendAudio(audio[i:end]); err != nil {
-			return "", err
+		if err := s.writeAudio(audio[i:end]); err != nil {
+			return err
 		}
 	}
 
-	if err := stream.SendEndOfStream(); err != nil {
-		return "", err
+	return s.SendEndOfStream()
+}
+
+// pumpReader runs sendReader and, if it fails, ends the stream with its
+// error.
+func (s *STTStream) pumpReader(ctx context.Context, r io.Reader) {
+	if err := s.sendReader(ctx, r); err != nil {
+		s.setError(err)
+		_ = s.Close()
+	}
+}
+
+// sendReader waits for the stream to be ready, then reads r one frame at a
+// time and sends each chunk, followed by end of stream once r is exhausted.
+// A final chunk shorter than a frame is sent as is.
+func (s *STTStream) sendReader(ctx context.Context, r io.Reader) error {
+	if _, err := s.WaitReady(ctx); err != nil {
+		return err
 	}
 
-	return stream.CollectText(ctx)
+	buf := make([]byte, s.readyInfoOrDefault().FrameSize*bytesPerSample)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if werr := s.writeAudio(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			return s.SendEndOfStream()
+		case err != nil:
+			return err
+		}
+	}
 }
 
 func (s *STTStream) handleMessages() {
-	defer close(s.done)
-	defer close(s.textCh)
-	defer close(s.vadCh)
-	defer close(s.endTextCh)
-	defer close(s.allMsgCh)
+	defer func() {
+		// Close the specialised channels before allIn, which lets forwardAll
+		// close allMsgCh, so All() never ends while Text(), VAD() or
+		// EndText() are still open. done is closed last.
+		for _, ch := range s.textStreams {
+			close(ch)
+		}
+		close(s.textCh)
+		close(s.discardedCh)
+		close(s.unknownCh)
+		close(s.vadCh)
+		close(s.endTextCh)
+		close(s.allIn)
+		close(s.done)
+		s.span.SetAttributes(SpanAttribute{Key: AttrAudioBytesTransferred, Value: s.audioBytes.Load()})
+		s.span.End(s.getError())
+		logStreamComplete(s.logger, s.audioBytes.Load(), s.getError())
+		s.signalDoneErr()
+	}()
 
 	readySignaled := false
 
@@ -176,6 +538,16 @@ func (s *STTStream) handleMessages() {
 				FrameSize:       readyMsg.FrameSize,
 				DelayInTokens:   readyMsg.DelayInTokens,
 				TextStreamNames: readyMsg.TextStreamNames,
+
+				DetectedLanguage: readyMsg.DetectedLanguage,
+			}
+			s.span.SetAttributes(SpanAttribute{Key: AttrRequestID, Value: readyMsg.RequestID})
+			s.logger.Info(logEventReady, AttrRequestID, readyMsg.RequestID)
+			if s.textStreams == nil {
+				s.textStreams = make([]chan STTTextResult, len(readyMsg.TextStreamNames))
+				for i := range s.textStreams {
+					s.textStreams[i] = make(chan STTTextResult, cap(s.textCh))
+				}
 			}
 			s.readyInfoMu.Unlock()
 			if !readySignaled {
@@ -188,18 +560,33 @@ func (s *STTStream) handleMessages() {
 			if err := json.Unmarshal(data, &textMsg); err != nil {
 				continue
 			}
+			s.firstTextAt.CompareAndSwap(0, time.Now().UnixNano())
 			result := STTTextResult{
-				Text:     textMsg.Text,
-				StartS:   textMsg.StartS,
-				StreamID: textMsg.StreamID,
+				Text:      textMsg.Text,
+				StartS:    textMsg.StartS,
+				StreamID:  textMsg.StreamID,
+				SpeakerID: textMsg.SpeakerID,
+				Words:     wordTimestamps(textMsg.Words),
+
+				Confidence: textMsg.Confidence,
+				Language:   textMsg.Language,
 			}
-			select {
-			case s.textCh <- result:
-			default:
+			if s.belowThreshold(result) {
+				if !trySend(s.discardedCh, result, &s.dropped) {
+					logDropped(s.logger, "discarded_text")
+				}
+				continue
 			}
-			select {
-			case s.allMsgCh <- result:
-			default:
+			s.publishAll(result)
+			s.tapSilence(result)
+			if !trySend(s.textCh, result, &s.dropped) {
+				logDropped(s.logger, "text")
+			}
+			if ch := s.textStreamFor(result.StreamID); ch != nil {
+				select {
+				case ch <- result:
+				default:
+				}
 			}
 
 		case "step":
@@ -213,13 +600,10 @@ func (s *STTStream) handleMessages() {
 				StepDurationS:  stepMsg.StepDurationS,
 				TotalDurationS: stepMsg.TotalDurationS,
 			}
-			select {
-			case s.vadCh <- result:
-			default:
-			}
-			select {
-			case s.allMsgCh <- result:
-			default:
+			s.publishAll(result)
+			s.tapSilence(result)
+			if !trySend(s.vadCh, result, &s.dropped) {
+				logDropped(s.logger, "vad")
 			}
 
 		case "end_text":
@@ -231,13 +615,9 @@ func (s *STTStream) handleMessages() {
 				StopS:    endMsg.StopS,
 				StreamID: endMsg.StreamID,
 			}
-			select {
-			case s.endTextCh <- result:
-			default:
-			}
-			select {
-			case s.allMsgCh <- result:
-			default:
+			s.publishAll(result)
+			if !trySend(s.endTextCh, result, &s.dropped) {
+				logDropped(s.logger, "end_text")
 			}
 
 		case msgTypeEndOfStream:
@@ -251,6 +631,71 @@ func (s *STTStream) handleMessages() {
 				close(s.ready)
 			}
 			return
+
+		default:
+			s.logger.Debug(logEventUnknownMessage, logKeyType, msg.Type)
+			event := STTUnknownEvent{Type: msg.Type, Raw: data}
+			s.publishAll(event)
+			select {
+			case s.unknownCh <- event:
+			default:
+			}
+		}
+	}
+}
+
+// publishAll hands a message to the All() fan-out goroutine. It only blocks
+// until the fan-out has queued the message, never on the All() consumer.
+// It is called synchronously from handleMessages, before the message is
+// dispatched to its dedicated channel, so All() preserves server order.
+func (s *STTStream) publishAll(msg interface{}) {
+	select {
+	case s.allIn <- msg:
+	case <-s.closed:
+	}
+}
+
+// forwardAll delivers every message received from handleMessages to allMsgCh
+// in arrival order. Once All has been called, messages are queued internally
+// so a slow All() consumer never causes drops; before that, messages that do
+// not fit in allMsgCh's buffer are discarded, so a stream whose All channel is
+// never read does not grow without bound. It closes allMsgCh once
+// handleMessages has finished and the queue is drained, or when the stream is
+// closed.
+func (s *STTStream) forwardAll() {
+	defer close(s.allMsgCh)
+
+	in := s.allIn
+	var queue []interface{}
+
+	for in != nil || len(queue) > 0 {
+		var out chan<- interface{}
+		var next interface{}
+		if len(queue) > 0 {
+			out = s.allMsgCh
+			next = queue[0]
+		}
+
+		select {
+		case msg, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			if !s.allRequested.Load() {
+				// The queue is empty until All is called, so order is kept.
+				select {
+				case s.allMsgCh <- msg:
+				default:
+				}
+				continue
+			}
+			queue = append(queue, msg)
+		case out <- next:
+			queue[0] = nil
+			queue = queue[1:]
+		case <-s.closed:
+			return
 		}
 	}
 }
@@ -270,13 +715,17 @@ func (s *STTStream) getError() error {
 }
 
 // WaitReady waits for the stream to be ready and returns the ready info.
-func (s *STTStream) WaitReady(ctx context.Context) (*STTReadyInfo, error) {
+// Once the stream is ready, further calls return the same info immediately.
+func (s *STTStream) WaitReady(ctx context.Context) (info *STTReadyInfo, err error) {
+	_, span := s.tracer.StartSpan(ctx, SpanSTTWaitReady)
+	defer func() { span.End(err) }()
+
 	select {
 	case <-s.ready:
 		if err := s.getError(); err != nil {
 			return nil, err
 		}
-		return s.readyInfo, nil
+		return s.ReadyInfo(), nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
@@ -284,22 +733,240 @@ func (s *STTStream) WaitReady(ctx context.Context) (*STTReadyInfo, error) {
 
 // SendAudio sends audio data to be transcribed.
 // Audio should be PCM 24kHz 16-bit mono.
+//
+// With InputFormatPCM, audio is sent in whole frames (FrameSize samples,
+// from the ready info): whatever does not fill a frame is held back and sent
+// with the next call that completes it, so sub-frame calls are fine. Flush,
+// or SendEndOfStream, sends the remainder padded with silence. Call
+// WaitReady first so the server's frame size is used rather than the
+// default. Other input formats, such as WAV and Opus, are sent unchanged.
+//
+// With STTParams.ValidateAudio, audio larger than MaxAudioChunkBytes, or PCM
+// audio that is not a whole number of frames, is rejected with an
+// AudioValidationError before anything is sent.
 func (s *STTStream) SendAudio(audio []byte) error {
+	if s.validateAudio {
+		if err := s.checkAudio(audio); err != nil {
+			return err
+		}
+	}
+
+	s.markFirstAudio(len(audio))
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if !s.rawPCM {
+		if len(audio) == 0 {
+			return nil
+		}
+		return s.writeAudioLocked(audio)
+	}
+
+	frameBytes := s.readyInfoOrDefault().FrameSize * bytesPerSample
+	if len(s.pending) > 0 {
+		n := min(frameBytes-len(s.pending), len(audio))
+		s.pending = append(s.pending, audio[:n]...)
+		audio = audio[n:]
+		if len(s.pending) < frameBytes {
+			return nil
+		}
+		if err := s.writeAudioLocked(s.pending); err != nil {
+			return err
+		}
+		s.pending = s.pending[:0]
+	}
+
+	whole := len(audio) - len(audio)%frameBytes
+	if whole > 0 {
+		if err := s.writeAudioLocked(audio[:whole]); err != nil {
+			return err
+		}
+	}
+	s.pending = append(s.pending, audio[whole:]...)
+	return nil
+}
+
+// Flush sends any PCM audio SendAudio is holding back because it does not
+// fill a frame, padded with silence (zero bytes) to a whole frame. It does
+// nothing if no audio is pending, which is always the case for input formats
+// other than PCM. SendEndOfStream calls it.
+func (s *STTStream) Flush() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.flushLocked()
+}
+
+// flushLocked implements Flush. s.writeMu must be held.
+func (s *STTStream) flushLocked() error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+
+	// Pad to a multiple rather than one frame in case the ready message
+	// lowered the frame size after the audio was buffered.
+	frameBytes := s.readyInfoOrDefault().FrameSize * bytesPerSample
+	padded := (len(s.pending) + frameBytes - 1) / frameBytes * frameBytes
+	s.pending = append(s.pending, make([]byte, padded-len(s.pending))...)
+	if err := s.writeAudioLocked(s.pending); err != nil {
+		return err
+	}
+	s.pending = s.pending[:0]
+	return nil
+}
+
+// checkAudio validates a SendAudio chunk against the stream's frame size and
+// MaxAudioChunkBytes.
+func (s *STTStream) checkAudio(audio []byte) error {
+	if len(audio) > MaxAudioChunkBytes {
+		return &AudioValidationError{Reason: "chunk too large", Expected: MaxAudioChunkBytes, Got: len(audio)}
+	}
+	if !s.rawPCM {
+		return nil
+	}
+	frameBytes := s.readyInfoOrDefault().FrameSize * bytesPerSample
+	if len(audio)%frameBytes != 0 {
+		return &AudioValidationError{Reason: "chunk is not a multiple of the frame size", Expected: frameBytes, Got: len(audio)}
+	}
+	return nil
+}
+
+// markFirstAudio records the time of the first non-empty audio passed to
+// the stream, which may be buffered before it is sent.
+func (s *STTStream) markFirstAudio(n int) {
+	if n > 0 && s.firstAudioAt.Load() == 0 &&
+		s.firstAudioAt.CompareAndSwap(0, time.Now().UnixNano()) {
+		s.logger.Info(logEventFirstChunk, logKeyBytes, n)
+	}
+}
+
+// writeAudio sends one audio message.
+func (s *STTStream) writeAudio(audio []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.writeAudioLocked(audio)
+}
+
+// writeAudioLocked sends one audio message. s.writeMu must be held.
+func (s *STTStream) writeAudioLocked(audio []byte) error {
+	s.markFirstAudio(len(audio))
+	s.logger.Debug(logEventAudioChunk, logKeyBytes, len(audio))
+	s.audioBytes.Add(int64(len(audio)))
 	encoded := base64.StdEncoding.EncodeToString(audio)
 	msg := sttAudioMessage{Type: "audio", Audio: encoded}
 	return s.conn.WriteJSON(msg)
 }
 
-// SendEndOfStream signals the end of audio input.
+// Dropped returns the number of results discarded so far because the Text,
+// DiscardedText, VAD or EndText channel was full. Results are still delivered on All, so
+// drops on channels the caller does not read are expected; otherwise raise
+// the buffer sizes with WithSTTChannelSizes or WithChannelBufferSize. With
+// WithLogger, each drop is also logged at WARN.
+func (s *STTStream) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// SpeakingSince returns the wall-clock time of the first non-empty SendAudio
+// call and true, or the zero time and false if no audio has been sent yet.
+func (s *STTStream) SpeakingSince() (time.Time, bool) {
+	nanos := s.firstAudioAt.Load()
+	if nanos == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+// LatencyToFirstToken returns how long the first text result took to arrive,
+// measured from the first non-empty SendAudio call, or 0 if no audio has been
+// sent or no text has arrived yet.
+func (s *STTStream) LatencyToFirstToken() time.Duration {
+	firstAudio := s.firstAudioAt.Load()
+	if firstAudio == 0 {
+		return 0
+	}
+	return max(sinceStart(time.Unix(0, firstAudio), s.firstTextAt.Load()), 0)
+}
+
+// SendEndOfStream signals the end of audio input, first flushing any audio
+// SendAudio is holding back (see Flush).
 func (s *STTStream) SendEndOfStream() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.flushLocked(); err != nil {
+		return err
+	}
 	return s.conn.WriteJSON(wsMessage{Type: msgTypeEndOfStream})
 }
 
 // Text returns a channel that receives transcription results.
+//
+// Results are buffered from the moment the stream is created, so none are
+// lost if the server sends text before the caller starts reading. Only
+// results beyond the buffer size (see WithSTTChannelSizes) are dropped.
 func (s *STTStream) Text() <-chan STTTextResult {
 	return s.textCh
 }
 
+// Unknown returns a channel that receives messages whose type the SDK does
+// not recognise, such as ones added to the server after this release. Events
+// are dropped when the channel is full, so leaving it unread is harmless;
+// size it with WithSTTUnknownBuffer. The same messages are delivered on All,
+// without drops, for callers that want them in order with the rest.
+func (s *STTStream) Unknown() <-chan STTUnknownEvent {
+	return s.unknownCh
+}
+
+// DiscardedText returns a channel that receives the transcription results
+// whose Confidence is below STTParams.ConfidenceThreshold. These results are
+// not delivered on Text, TextStream or All, so CollectText and the other
+// collectors never include them. The channel is buffered like Text.
+func (s *STTStream) DiscardedText() <-chan STTTextResult {
+	return s.discardedCh
+}
+
+// belowThreshold reports whether result falls below the stream's confidence
+// threshold. Results without a confidence are never discarded.
+func (s *STTStream) belowThreshold(result STTTextResult) bool {
+	return s.confidenceThreshold != nil && result.Confidence != nil &&
+		*result.Confidence < *s.confidenceThreshold
+}
+
+// TextStream returns a channel that receives only the transcription results
+// of the named text stream. Names map to stream IDs by their index in
+// ReadyInfo().TextStreamNames, so TextStream must be called after WaitReady.
+// Results without a stream ID belong to the first stream.
+//
+// Every result is still delivered on Text as well; each channel has its own
+// buffer and drops results when full.
+func (s *STTStream) TextStream(name string) (<-chan STTTextResult, error) {
+	s.readyInfoMu.RLock()
+	defer s.readyInfoMu.RUnlock()
+
+	if s.readyInfo == nil {
+		return nil, &Error{Message: "stream is not ready"}
+	}
+	for i, streamName := range s.readyInfo.TextStreamNames {
+		if streamName == name {
+			return s.textStreams[i], nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("unknown text stream %q", name)}
+}
+
+// textStreamFor returns the per-stream channel for a stream ID, or nil if
+// the ID does not match a stream announced in the ready message. It must
+// only be called from handleMessages.
+func (s *STTStream) textStreamFor(streamID *int) chan STTTextResult {
+	id := 0
+	if streamID != nil {
+		id = *streamID
+	}
+	if id < 0 || id >= len(s.textStreams) {
+		return nil
+	}
+	return s.textStreams[id]
+}
+
 // VAD returns a channel that receives voice activity detection results.
 func (s *STTStream) VAD() <-chan STTStepResult {
 	return s.vadCh
@@ -310,49 +977,338 @@ func (s *STTStream) EndText() <-chan STTEndTextResult {
 	return s.endTextCh
 }
 
-// All returns a channel that receives all message types.
+// All returns a channel that receives all message types: STTTextResult,
+// STTStepResult, STTEndTextResult, and STTUnknownEvent for message types the
+// SDK does not recognise.
+// Unlike Text, VAD and EndText, messages are never dropped on this channel
+// once All has been called; they are queued until read or until the stream is
+// closed. Messages that arrive before the first call are kept only up to the
+// All buffer size (see WithSTTChannelSizes), so call All before sending audio
+// to receive everything.
 func (s *STTStream) All() <-chan interface{} {
+	s.allRequested.Store(true)
 	return s.allMsgCh
 }
 
 // CollectText waits for all text and returns the combined transcription.
-func (s *STTStream) CollectText(ctx context.Context) (string, error) {
-	var texts []string
+func (s *STTStream) CollectText(ctx context.Context) (text string, err error) {
+	ctx, span := s.tracer.StartSpan(ctx, SpanSTTCollectText)
+	defer func() { span.End(err) }()
+
+	results, err := s.collectTextResults(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return joinText(results), nil
+}
+
+// collectTextResults waits for the text channel to close and returns every
+// result received.
+func (s *STTStream) collectTextResults(ctx context.Context) ([]STTTextResult, error) {
+	var results []STTTextResult
 
 	for {
 		select {
 		case text, ok := <-s.textCh:
 			if !ok {
 				if err := s.getError(); err != nil {
-					return "", err
+					return nil, err
 				}
-				return strings.Join(texts, " "), nil
+				return results, nil
 			}
-			texts = append(texts, text.Text)
+			results = append(results, text)
 
 		case <-ctx.Done():
-			return "", ctx.Err()
+			return nil, ctx.Err()
 		}
 	}
 }
 
-// ReadyInfo returns the ready info (nil if not ready yet).
+// CollectUtterances reads All until the stream ends and groups the words of
+// the first text stream into utterances. A text result's Words are used when
+// the server sends them (see STTParams.RequestWordTimestamps); otherwise the
+// result becomes a single word that ends at the next end_text marker, or at
+// the start of the following word if the server sends none. A new utterance
+// starts whenever the SpeakerID changes, so without diarisation the whole
+// transcript is one utterance.
+//
+// Because it consumes All, CollectUtterances must not be combined with other
+// readers of All.
+func (s *STTStream) CollectUtterances(ctx context.Context) ([]Utterance, error) {
+	all := s.All()
+	var utterances []Utterance
+	var pending *STTTextResult
+
+	finish := func(stopS float64) {
+		words := pending.Words
+		if len(words) == 0 {
+			words = []WordTimestamp{{Text: pending.Text, StartS: pending.StartS, StopS: stopS}}
+		}
+		last := len(utterances) - 1
+		if last < 0 || !sameSpeaker(utterances[last].SpeakerID, pending.SpeakerID) {
+			utterances = append(utterances, Utterance{StartS: words[0].StartS, SpeakerID: pending.SpeakerID})
+			last++
+		}
+		u := &utterances[last]
+		if u.Text != "" {
+			u.Text += " "
+		}
+		u.Text += pending.Text
+		u.StopS = words[len(words)-1].StopS
+		u.Words = append(u.Words, words...)
+		pending = nil
+	}
+
+	for {
+		select {
+		case msg, ok := <-all:
+			if !ok {
+				if err := s.getError(); err != nil {
+					return nil, err
+				}
+				if pending != nil {
+					finish(pending.StartS)
+				}
+				return utterances, nil
+			}
+
+			switch m := msg.(type) {
+			case STTTextResult:
+				if !isFirstStream(m.StreamID) {
+					continue
+				}
+				if pending != nil {
+					finish(m.StartS)
+				}
+				pending = &m
+			case STTEndTextResult:
+				if isFirstStream(m.StreamID) && pending != nil {
+					finish(m.StopS)
+				}
+			}
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// CollectDiarizedTranscript reads All until the stream ends and returns the
+// transcript of the first text stream as one segment per speaker turn. It
+// groups words like CollectUtterances, taking each segment's EndS from the
+// end_text marker of the same stream. Text without a SpeakerID, as sent when
+// STTParams.Diarization is unset, is attributed to speaker 0.
+//
+// Because it consumes All, CollectDiarizedTranscript must not be combined
+// with other readers of All.
+func (s *STTStream) CollectDiarizedTranscript(ctx context.Context) ([]SpeakerSegment, error) {
+	utterances, err := s.CollectUtterances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]SpeakerSegment, len(utterances))
+	for i, u := range utterances {
+		segments[i] = SpeakerSegment{Text: u.Text, StartS: u.StartS, EndS: u.StopS}
+		if u.SpeakerID != nil {
+			segments[i].SpeakerID = *u.SpeakerID
+		}
+	}
+	return segments, nil
+}
+
+// CollectSegments reads All until the stream ends and returns the transcript
+// as segments: the text results of each text stream are joined with spaces
+// until that stream's next end_text marker, whose StopS becomes the segment's
+// EndS. Segments are ordered by their first text result. A segment still
+// open when the stream ends has EndS -1, meaning unknown; FormatSRT and
+// FormatVTT fill such ends in from the following segment.
+//
+// If the stream fails or ctx is done, the segments received so far are
+// returned along with the error, with EndS -1 on those left open.
+//
+// It reads All rather than Text and EndText so that each end_text marker is
+// matched against the text that preceded it, and so must not be combined
+// with other readers of All.
+func (s *STTStream) CollectSegments(ctx context.Context) ([]STTSegment, error) {
+	all := s.All()
+	var segments []STTSegment
+	open := make(map[int]int) // stream index to the position of its open segment
+
+	for {
+		select {
+		case msg, ok := <-all:
+			if !ok {
+				return segments, s.getError()
+			}
+
+			switch m := msg.(type) {
+			case STTTextResult:
+				streamID := streamIndex(m.StreamID)
+				if i, ok := open[streamID]; ok {
+					segments[i].Text += " " + m.Text
+					continue
+				}
+				open[streamID] = len(segments)
+				segments = append(segments, STTSegment{
+					Text:      m.Text,
+					StartS:    m.StartS,
+					EndS:      -1,
+					SpeakerID: m.SpeakerID,
+					StreamID:  streamID,
+				})
+			case STTEndTextResult:
+				streamID := streamIndex(m.StreamID)
+				if i, ok := open[streamID]; ok {
+					segments[i].EndS = m.StopS
+					delete(open, streamID)
+				}
+			}
+
+		case <-ctx.Done():
+			return segments, ctx.Err()
+		}
+	}
+}
+
+// wordTimestamps converts the words of a text message, returning nil when
+// there are none.
+func wordTimestamps(words []sttWordMessage) []WordTimestamp {
+	if len(words) == 0 {
+		return nil
+	}
+	out := make([]WordTimestamp, len(words))
+	for i, w := range words {
+		out[i] = WordTimestamp{Text: w.Text, StartS: w.StartS, StopS: w.EndS, Confidence: w.Confidence}
+	}
+	return out
+}
+
+// streamIndex returns the text stream index of streamID; results without a
+// stream ID belong to the first stream.
+func streamIndex(streamID *int) int {
+	if streamID == nil {
+		return 0
+	}
+	return *streamID
+}
+
+// isFirstStream reports whether streamID refers to the first text stream;
+// results without a stream ID belong to it.
+func isFirstStream(streamID *int) bool {
+	return streamID == nil || *streamID == 0
+}
+
+// sameSpeaker reports whether two optional speaker IDs are equal.
+func sameSpeaker(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// joinText joins the text of each result with spaces.
+func joinText(results []STTTextResult) string {
+	texts := make([]string, len(results))
+	for i, r := range results {
+		texts[i] = r.Text
+	}
+	return strings.Join(texts, " ")
+}
+
+// ReadyInfo returns the ready info. It returns nil until WaitReady succeeds,
+// and stays nil if the stream fails before the server reports ready, so
+// callers must check the result before dereferencing it.
 func (s *STTStream) ReadyInfo() *STTReadyInfo {
 	s.readyInfoMu.RLock()
 	defer s.readyInfoMu.RUnlock()
 	return s.readyInfo
 }
 
-// Close closes the stream.
+// DetectedLanguage returns the language detected with
+// STTParams.AutoDetectLanguage, or nil if the stream is not ready yet or the
+// server did not report one.
+func (s *STTStream) DetectedLanguage() *string {
+	if info := s.ReadyInfo(); info != nil {
+		return info.DetectedLanguage
+	}
+	return nil
+}
+
+// readyInfoOrDefault returns a copy of the ready info with SampleRate and
+// FrameSize set to the protocol defaults if the server has not reported
+// them, or if the stream is not ready yet.
+func (s *STTStream) readyInfoOrDefault() STTReadyInfo {
+	var info STTReadyInfo
+	if ready := s.ReadyInfo(); ready != nil {
+		info = *ready
+	}
+	if info.SampleRate <= 0 {
+		info.SampleRate = defaultSampleRate
+	}
+	if info.FrameSize <= 0 {
+		info.FrameSize = defaultFrameSize
+	}
+	return info
+}
+
+// Close closes the stream. Results still buffered on Text, VAD, EndText and
+// All are discarded in the background; callers should not read them after
+// Close.
 func (s *STTStream) Close() error {
 	var err error
 	s.closeOnce.Do(func() {
+		close(s.closed)
 		err = s.conn.Close()
+		go s.drain()
 	})
 	return err
 }
 
+// drain discards any results left on the public channels until they are
+// closed, so a caller that stopped reading early never holds up
+// handleMessages or forwardAll after Close.
+func (s *STTStream) drain() {
+	textCh, vadCh, endTextCh, allCh := s.textCh, s.vadCh, s.endTextCh, s.allMsgCh
+
+	for textCh != nil || vadCh != nil || endTextCh != nil || allCh != nil {
+		select {
+		case _, ok := <-textCh:
+			if !ok {
+				textCh = nil
+			}
+		case _, ok := <-vadCh:
+			if !ok {
+				vadCh = nil
+			}
+		case _, ok := <-endTextCh:
+			if !ok {
+				endTextCh = nil
+			}
+		case _, ok := <-allCh:
+			if !ok {
+				allCh = nil
+			}
+		}
+	}
+}
+
 // Done returns a channel that's closed when the stream ends.
 func (s *STTStream) Done() <-chan struct{} {
 	return s.done
 }
+
+// DoneErr returns a channel that receives exactly one value when the stream
+// ends and is then closed. The value is nil after a clean end of stream, or
+// the error that ended it otherwise.
+func (s *STTStream) DoneErr() <-chan error {
+	return s.doneErr
+}
+
+// signalDoneErr delivers the final stream error on doneErr. It runs once,
+// when handleMessages returns.
+func (s *STTStream) signalDoneErr() {
+	s.doneErr <- s.getError()
+	close(s.doneErr)
+}
@@ -1,13 +1,20 @@
 package gradium
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -116,70 +123,165 @@ func TestSTTStream_WaitReadyTimeout(t *testing.T) {
 }
 
 func TestSTTStream_SendAudio(t *testing.T) {
-	var receivedAudio []byte
-	var mu sync.Mutex
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		conn, err := wsUpgrader.Upgrade(w, r, nil)
-		if err != nil {
-			return
-		}
-		defer conn.Close()
-
-		// Read setup
-		var setup sttSetupMessage
-		conn.ReadJSON(&setup)
-
-		// Send ready
-		conn.WriteJSON(map[string]interface{}{
-			"type":              "ready",
-			"request_id":        "req-123",
-			"model_name":        "default",
-			"sample_rate":       24000,
-			"frame_size":        1920,
-			"delay_in_tokens":   5,
-			"text_stream_names": []string{"main"},
-		})
-
-		// Read audio message
-		var audioMsg sttAudioMessage
-		if err := conn.ReadJSON(&audioMsg); err == nil {
-			decoded, _ := base64.StdEncoding.DecodeString(audioMsg.Audio)
-			mu.Lock()
-			receivedAudio = decoded
-			mu.Unlock()
-		}
-
-		time.Sleep(100 * time.Millisecond)
-	}))
-	defer server.Close()
-
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
-	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
-	client.wsURL = wsURL
-
-	stream, _ := client.STT.Stream(context.Background(), STTParams{
-		InputFormat: InputFormatPCM,
-	})
-	defer stream.Close()
+	const frameBytes = 1920 * 2
+
+	tests := []struct {
+		name       string
+		format     InputFormat // defaults to InputFormatPCM
+		send       func(s *STTStream) error
+		wantChunks []int
+		wantAudio  []byte
+	}{
+		{
+			name: "sub-frame audio is padded on end of stream",
+			send: func(s *STTStream) error {
+				return s.SendAudio([]byte("test audio samples"))
+			},
+			wantChunks: []int{frameBytes},
+			wantAudio:  append([]byte("test audio samples"), make([]byte, frameBytes-18)...),
+		},
+		{
+			name: "sub-frame calls accumulate into a frame",
+			send: func(s *STTStream) error {
+				if err := s.SendAudio(bytes.Repeat([]byte{1}, 1000)); err != nil {
+					return err
+				}
+				return s.SendAudio(bytes.Repeat([]byte{1}, frameBytes-1000))
+			},
+			wantChunks: []int{frameBytes},
+			wantAudio:  bytes.Repeat([]byte{1}, frameBytes),
+		},
+		{
+			name: "whole frames are sent at once",
+			send: func(s *STTStream) error {
+				return s.SendAudio(bytes.Repeat([]byte{1}, 2*frameBytes+10))
+			},
+			wantChunks: []int{2 * frameBytes, frameBytes},
+			wantAudio:  append(bytes.Repeat([]byte{1}, 2*frameBytes+10), make([]byte, frameBytes-10)...),
+		},
+		{
+			name: "explicit flush",
+			send: func(s *STTStream) error {
+				if err := s.SendAudio(bytes.Repeat([]byte{1}, 100)); err != nil {
+					return err
+				}
+				if err := s.Flush(); err != nil {
+					return err
+				}
+				if err := s.Flush(); err != nil {
+					return err
+				}
+				return s.SendAudio(bytes.Repeat([]byte{2}, frameBytes))
+			},
+			wantChunks: []int{frameBytes, frameBytes},
+			wantAudio:  slices.Concat(bytes.Repeat([]byte{1}, 100), make([]byte, frameBytes-100), bytes.Repeat([]byte{2}, frameBytes)),
+		},
+		{
+			name:   "wav is sent unchanged",
+			format: InputFormatWAV,
+			send: func(s *STTStream) error {
+				if err := s.SendAudio([]byte("RIFF header")); err != nil {
+					return err
+				}
+				return s.SendAudio(bytes.Repeat([]byte{1}, frameBytes+10))
+			},
+			wantChunks: []int{11, frameBytes + 10},
+			wantAudio:  append([]byte("RIFF header"), bytes.Repeat([]byte{1}, frameBytes+10)...),
+		},
+		{
+			name:   "opus is not padded on flush",
+			format: InputFormatOpus,
+			send: func(s *STTStream) error {
+				if err := s.SendAudio([]byte("OggS page")); err != nil {
+					return err
+				}
+				return s.Flush()
+			},
+			wantChunks: []int{9},
+			wantAudio:  []byte("OggS page"),
+		},
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunkSizes []int
+			var receivedAudio []byte
+			received := make(chan struct{})
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				// Read setup
+				var setup sttSetupMessage
+				conn.ReadJSON(&setup)
+
+				// Send ready
+				conn.WriteJSON(map[string]interface{}{
+					"type":              "ready",
+					"request_id":        "req-123",
+					"model_name":        "default",
+					"sample_rate":       24000,
+					"frame_size":        1920,
+					"delay_in_tokens":   5,
+					"text_stream_names": []string{"main"},
+				})
+
+				// Read audio messages until end of stream
+				defer close(received)
+				for {
+					var audioMsg sttAudioMessage
+					if err := conn.ReadJSON(&audioMsg); err != nil || audioMsg.Type == "end_of_stream" {
+						return
+					}
+					decoded, _ := base64.StdEncoding.DecodeString(audioMsg.Audio)
+					chunkSizes = append(chunkSizes, len(decoded))
+					receivedAudio = append(receivedAudio, decoded...)
+				}
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			format := tt.format
+			if format == "" {
+				format = InputFormatPCM
+			}
+			stream, _ := client.STT.Stream(context.Background(), STTParams{
+				InputFormat: format,
+			})
+			defer stream.Close()
 
-	stream.WaitReady(ctx)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
 
-	audioData := []byte("test audio samples")
-	if err := stream.SendAudio(audioData); err != nil {
-		t.Errorf("SendAudio failed: %v", err)
-	}
+			stream.WaitReady(ctx)
 
-	time.Sleep(50 * time.Millisecond)
+			if err := tt.send(stream); err != nil {
+				t.Fatalf("SendAudio failed: %v", err)
+			}
+			if err := stream.SendEndOfStream(); err != nil {
+				t.Fatalf("SendEndOfStream failed: %v", err)
+			}
 
-	mu.Lock()
-	if string(receivedAudio) != string(audioData) {
-		t.Errorf("expected audio %q, got %q", string(audioData), string(receivedAudio))
+			select {
+			case <-received:
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for audio")
+			}
+			if !reflect.DeepEqual(chunkSizes, tt.wantChunks) {
+				t.Errorf("expected chunks %v, got %v", tt.wantChunks, chunkSizes)
+			}
+			if !bytes.Equal(receivedAudio, tt.wantAudio) {
+				t.Errorf("expected %d bytes of audio %q..., got %d bytes %q...", len(tt.wantAudio), tt.wantAudio[:20], len(receivedAudio), receivedAudio[:min(20, len(receivedAudio))])
+			}
+		})
 	}
-	mu.Unlock()
 }
 
 func TestSTTStream_ReceiveText(t *testing.T) {
@@ -406,6 +508,236 @@ func TestSTTService_Transcribe(t *testing.T) {
 	}
 }
 
+func TestSTTService_TranscribeDetailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-detailed",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main"},
+		})
+
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+		}
+
+		conn.WriteJSON(map[string]interface{}{
+			"type":    "text",
+			"text":    "Hello",
+			"start_s": 0.1,
+		})
+		conn.WriteJSON(map[string]interface{}{
+			"type":    "text",
+			"text":    "world",
+			"start_s": 0.6,
+		})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := client.STT.TranscribeDetailed(ctx, STTParams{
+		InputFormat: InputFormatPCM,
+	}, make([]byte, 5000))
+	if err != nil {
+		t.Fatalf("TranscribeDetailed failed: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Text != "Hello" || results[0].StartS != 0.1 {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if results[1].Text != "world" || results[1].StartS != 0.6 {
+		t.Errorf("unexpected second result: %+v", results[1])
+	}
+}
+
+func TestSTTService_TranscribeUsesFrameSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		frameSize int
+		chunkSize int
+	}{
+		{name: "16kHz", frameSize: 960, chunkSize: 1920},
+		{name: "24kHz", frameSize: 1920, chunkSize: 3840},
+		{name: "missing frame size", frameSize: 0, chunkSize: 3840},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunkSizes []int
+			var mu sync.Mutex
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup sttSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]interface{}{
+					"type":              "ready",
+					"request_id":        "req-123",
+					"model_name":        "default",
+					"sample_rate":       16000,
+					"frame_size":        tt.frameSize,
+					"delay_in_tokens":   5,
+					"text_stream_names": []string{"main"},
+				})
+
+				for {
+					var msg sttAudioMessage
+					if err := conn.ReadJSON(&msg); err != nil {
+						return
+					}
+					if msg.Type == "end_of_stream" {
+						break
+					}
+					decoded, _ := base64.StdEncoding.DecodeString(msg.Audio)
+					mu.Lock()
+					chunkSizes = append(chunkSizes, len(decoded))
+					mu.Unlock()
+				}
+
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			// Two and a half chunks
+			audioData := make([]byte, tt.chunkSize*5/2)
+			if _, err := client.STT.Transcribe(ctx, STTParams{InputFormat: InputFormatPCM}, audioData); err != nil {
+				t.Fatalf("Transcribe failed: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			expected := []int{tt.chunkSize, tt.chunkSize, tt.chunkSize / 2}
+			if len(chunkSizes) != len(expected) {
+				t.Fatalf("expected chunks %v, got %v", expected, chunkSizes)
+			}
+			for i := range expected {
+				if chunkSizes[i] != expected[i] {
+					t.Errorf("chunk %d: expected %d bytes, got %d", i, expected[i], chunkSizes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSTTService_VADOnly(t *testing.T) {
+	const stepCount = 150
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-vad",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main"},
+		})
+
+		// Read all audio chunks and EOS
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+		}
+
+		// Send more steps than the VAD channel buffer, interleaved with text
+		for i := 0; i < stepCount; i++ {
+			conn.WriteJSON(map[string]interface{}{
+				"type": "step",
+				"vad": []map[string]interface{}{
+					{"horizon_s": 0.5, "inactivity_prob": 0.1},
+				},
+				"step_idx":         i,
+				"step_duration_s":  0.08,
+				"total_duration_s": 0.08 * float64(i+1),
+			})
+			if i%50 == 0 {
+				conn.WriteJSON(map[string]interface{}{
+					"type":    "text",
+					"text":    "ignored",
+					"start_s": 0.0,
+				})
+			}
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	steps, err := client.STT.VADOnly(ctx, STTParams{
+		InputFormat: InputFormatPCM,
+	}, make([]byte, 10000))
+	if err != nil {
+		t.Fatalf("VADOnly failed: %v", err)
+	}
+
+	if len(steps) != stepCount {
+		t.Fatalf("expected %d steps, got %d", stepCount, len(steps))
+	}
+	for i, step := range steps {
+		if step.StepIdx != i {
+			t.Errorf("expected step_idx %d, got %d", i, step.StepIdx)
+		}
+	}
+}
+
 func TestSTTStream_VAD(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		conn, err := wsUpgrader.Upgrade(w, r, nil)
@@ -889,3 +1221,1919 @@ func TestSTTStream_DefaultModelName(t *testing.T) {
 	}
 	mu.Unlock()
 }
+
+func TestSTTStream_AllNoDropSlowConsumer(t *testing.T) {
+	const stepCount = 300
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-123",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main"},
+		})
+
+		// Send more steps than any channel buffer can hold
+		for i := 0; i < stepCount; i++ {
+			conn.WriteJSON(map[string]interface{}{
+				"type":             "step",
+				"vad":              []map[string]interface{}{},
+				"step_idx":         i,
+				"step_duration_s":  0.08,
+				"total_duration_s": 0.08 * float64(i+1),
+			})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+	defer stream.Close()
+	all := stream.All()
+
+	// Don't read All() until the server has finished sending
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done channel not closed within timeout")
+	}
+
+	received := 0
+	for msg := range all {
+		step, ok := msg.(STTStepResult)
+		if !ok {
+			t.Fatalf("expected STTStepResult, got %T", msg)
+		}
+		if step.StepIdx != received {
+			t.Errorf("expected step_idx %d, got %d", received, step.StepIdx)
+		}
+		received++
+	}
+
+	if received != stepCount {
+		t.Errorf("expected %d messages, got %d", stepCount, received)
+	}
+}
+
+func TestSTTStream_AllBufferedUntilRequested(t *testing.T) {
+	const stepCount, allSize = 50, 4
+
+	tests := []struct {
+		name         string
+		requestFirst bool
+		want         int
+	}{
+		{name: "requested before messages", requestFirst: true, want: stepCount},
+		{name: "never requested keeps the buffer only", want: allSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup sttSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123", "sample_rate": 24000, "frame_size": 1920})
+				for i := 0; i < stepCount; i++ {
+					conn.WriteJSON(map[string]interface{}{"type": "step", "vad": []map[string]interface{}{}, "step_idx": i})
+				}
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithSTTChannelSizes(0, 0, 0, allSize))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+			if tt.requestFirst {
+				stream.All()
+			}
+
+			select {
+			case <-stream.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("Done channel not closed within timeout")
+			}
+
+			// Read allMsgCh directly: calling All here would start queueing
+			received := 0
+			for msg := range stream.allMsgCh {
+				if step := msg.(STTStepResult); step.StepIdx != received {
+					t.Errorf("expected step_idx %d, got %d", received, step.StepIdx)
+				}
+				received++
+			}
+			if received != tt.want {
+				t.Errorf("expected %d messages, got %d", tt.want, received)
+			}
+		})
+	}
+}
+
+func TestSTTStream_WaitReadyIdempotent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-123",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main"},
+		})
+
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first, err := stream.WaitReady(ctx)
+	if err != nil {
+		t.Fatalf("first WaitReady failed: %v", err)
+	}
+
+	start := time.Now()
+	second, err := stream.WaitReady(ctx)
+	if err != nil {
+		t.Fatalf("second WaitReady failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected second WaitReady to return immediately, took %v", elapsed)
+	}
+	if second != first {
+		t.Error("expected second WaitReady to return the same info")
+	}
+}
+
+func TestSTTStream_ReadyInfoNilAfterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+
+		// Fail before ready
+		conn.WriteJSON(map[string]interface{}{
+			"type":    "error",
+			"message": "Invalid API key",
+			"code":    401,
+		})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	info, err := stream.WaitReady(ctx)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if info != nil {
+		t.Errorf("expected nil info on error, got %+v", info)
+	}
+
+	<-stream.Done()
+	if stream.ReadyInfo() != nil {
+		t.Error("expected ReadyInfo to remain nil after error")
+	}
+}
+
+func TestSTTStream_SpeakingSince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-123",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main"},
+		})
+
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream.WaitReady(ctx)
+
+	if _, ok := stream.SpeakingSince(); ok {
+		t.Error("expected SpeakingSince to be unset before any audio")
+	}
+
+	// Empty audio doesn't count
+	stream.SendAudio(nil)
+	if _, ok := stream.SpeakingSince(); ok {
+		t.Error("expected SpeakingSince to be unset after empty audio")
+	}
+
+	before := time.Now()
+	stream.SendAudio([]byte("audio"))
+	after := time.Now()
+
+	first, ok := stream.SpeakingSince()
+	if !ok {
+		t.Fatal("expected SpeakingSince to be set")
+	}
+	if first.Before(before) || first.After(after) {
+		t.Errorf("expected SpeakingSince between %v and %v, got %v", before, after, first)
+	}
+
+	// Later audio doesn't move the timestamp
+	time.Sleep(10 * time.Millisecond)
+	stream.SendAudio([]byte("more audio"))
+	if again, _ := stream.SpeakingSince(); !again.Equal(first) {
+		t.Errorf("expected SpeakingSince to stay %v, got %v", first, again)
+	}
+}
+
+func TestSTTStream_AllArrivalOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-123",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main"},
+		})
+
+		// Interleave text, step and end_text messages in rapid succession
+		for i := 0; i < 50; i++ {
+			conn.WriteJSON(map[string]interface{}{
+				"type":    "text",
+				"text":    "word",
+				"start_s": float64(i),
+			})
+			conn.WriteJSON(map[string]interface{}{
+				"type":             "step",
+				"vad":              []map[string]interface{}{},
+				"step_idx":         i,
+				"step_duration_s":  0.08,
+				"total_duration_s": 0.08,
+			})
+			conn.WriteJSON(map[string]interface{}{
+				"type":   "end_text",
+				"stop_s": float64(i) + 0.5,
+			})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+	defer stream.Close()
+
+	i := 0
+	for msg := range stream.All() {
+		idx := i / 3
+		switch i % 3 {
+		case 0:
+			text, ok := msg.(STTTextResult)
+			if !ok || text.StartS != float64(idx) {
+				t.Fatalf("message %d: expected text with start_s %d, got %#v", i, idx, msg)
+			}
+		case 1:
+			step, ok := msg.(STTStepResult)
+			if !ok || step.StepIdx != idx {
+				t.Fatalf("message %d: expected step %d, got %#v", i, idx, msg)
+			}
+		case 2:
+			endText, ok := msg.(STTEndTextResult)
+			if !ok || endText.StopS != float64(idx)+0.5 {
+				t.Fatalf("message %d: expected end_text with stop_s %v, got %#v", i, float64(idx)+0.5, msg)
+			}
+		}
+		i++
+	}
+
+	if i != 150 {
+		t.Errorf("expected 150 messages, got %d", i)
+	}
+}
+
+func TestSTTStream_ChannelSizes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithSTTChannelSizes(5, 50, 2, 20))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	defer stream.Close()
+
+	if c := cap(stream.Text()); c != 5 {
+		t.Errorf("expected text channel capacity 5, got %d", c)
+	}
+	if c := cap(stream.VAD()); c != 50 {
+		t.Errorf("expected VAD channel capacity 50, got %d", c)
+	}
+	if c := cap(stream.EndText()); c != 2 {
+		t.Errorf("expected end text channel capacity 2, got %d", c)
+	}
+	if c := cap(stream.All()); c != 20 {
+		t.Errorf("expected all channel capacity 20, got %d", c)
+	}
+}
+
+func TestSTTStream_HandshakeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"detail": "Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	_, err := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+	})
+
+	connErr, ok := err.(*ConnectionError)
+	if !ok {
+		t.Fatalf("expected ConnectionError, got %T", err)
+	}
+
+	for _, want := range []string{
+		"STT WebSocket",
+		"status 401",
+		"content-type application/json",
+		"missing headers Upgrade, Connection, Sec-WebSocket-Accept",
+		"Invalid API key",
+	} {
+		if !strings.Contains(connErr.Message, want) {
+			t.Errorf("expected error to contain %q, got %q", want, connErr.Message)
+		}
+	}
+}
+
+func TestSTTStream_TextStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":              "ready",
+			"request_id":        "req-123",
+			"model_name":        "default",
+			"sample_rate":       24000,
+			"frame_size":        1920,
+			"delay_in_tokens":   5,
+			"text_stream_names": []string{"main", "partial"},
+		})
+
+		// Wait for the client to subscribe before sending text
+		var msg wsMessage
+		conn.ReadJSON(&msg)
+
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 0.0})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hel", "start_s": 0.0, "stream_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "world", "start_s": 0.5, "stream_id": 0})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "ignored", "start_s": 0.5, "stream_id": 7})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if _, err := stream.TextStream("main"); err == nil {
+		t.Error("expected error before ready")
+	}
+
+	if _, err := stream.WaitReady(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := stream.TextStream("unknown"); err == nil {
+		t.Error("expected error for unknown stream name")
+	}
+
+	mainCh, err := stream.TextStream("main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	partialCh, err := stream.TextStream("partial")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := stream.SendEndOfStream(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var mainTexts, partialTexts []string
+	for r := range mainCh {
+		mainTexts = append(mainTexts, r.Text)
+	}
+	for r := range partialCh {
+		partialTexts = append(partialTexts, r.Text)
+	}
+
+	if strings.Join(mainTexts, " ") != "Hello world" {
+		t.Errorf("expected main texts [Hello world], got %v", mainTexts)
+	}
+	if len(partialTexts) != 1 || partialTexts[0] != "Hel" {
+		t.Errorf("expected partial texts [Hel], got %v", partialTexts)
+	}
+
+	var all []string
+	for r := range stream.Text() {
+		all = append(all, r.Text)
+	}
+	if len(all) != 4 {
+		t.Errorf("expected Text to receive all 4 results, got %v", all)
+	}
+}
+
+func TestSTTStream_DoneErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		final   map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:  "clean end of stream",
+			final: map[string]interface{}{"type": "end_of_stream"},
+		},
+		{
+			name:    "server error",
+			final:   map[string]interface{}{"type": "error", "message": "boom", "code": 500},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup sttSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(tt.final)
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			stream, _ := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+			defer stream.Close()
+
+			select {
+			case err := <-stream.DoneErr():
+				var wsErr *WebSocketError
+				if tt.wantErr && !errors.As(err, &wsErr) {
+					t.Errorf("expected WebSocketError, got %v", err)
+				}
+				if !tt.wantErr && err != nil {
+					t.Errorf("expected nil error, got %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("DoneErr did not deliver within timeout")
+			}
+
+			if _, ok := <-stream.DoneErr(); ok {
+				t.Error("expected DoneErr channel to be closed after one value")
+			}
+			select {
+			case <-stream.Done():
+			default:
+				t.Error("expected Done to be closed")
+			}
+		})
+	}
+}
+
+func TestSTTStream_CloseDrainsChannels(t *testing.T) {
+	sent := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+
+		// Overfill every channel; the client never reads them
+		for i := 0; i < 20; i++ {
+			conn.WriteJSON(map[string]interface{}{"type": "text", "text": "word", "start_s": float64(i)})
+			conn.WriteJSON(map[string]interface{}{"type": "step", "vad": []interface{}{}, "step_idx": i})
+			conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": float64(i)})
+		}
+		close(sent)
+
+		var msg wsMessage
+		conn.ReadJSON(&msg)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithSTTChannelSizes(1, 1, 1, 1))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	<-sent
+	stream.Close()
+
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("handleMessages did not exit after Close")
+	}
+
+	// Both channels must be closed now; these loops would hang otherwise
+	for range stream.Text() {
+	}
+	for range stream.All() {
+	}
+}
+
+func TestSTTStream_AllClosesAfterSpecialisedChannels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 0.0})
+		conn.WriteJSON(map[string]interface{}{"type": "step", "vad": []interface{}{}, "step_idx": 0})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 0.5})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	for range stream.All() {
+	}
+
+	// Once All() has closed, the other channels must hold only buffered
+	// results and then report closed without blocking.
+	if !closedAfterBuffered(stream.Text()) {
+		t.Error("Text still open after All() closed")
+	}
+	if !closedAfterBuffered(stream.VAD()) {
+		t.Error("VAD still open after All() closed")
+	}
+	if !closedAfterBuffered(stream.EndText()) {
+		t.Error("EndText still open after All() closed")
+	}
+}
+
+// closedAfterBuffered reports whether ch is closed once its buffered values
+// are read, without blocking.
+func closedAfterBuffered[T any](ch <-chan T) bool {
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func TestSTTStream_UnknownMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]interface{}{"type": "speaker_change", "speaker": 2})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 0.0})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	var msgs []interface{}
+	for msg := range stream.All() {
+		msgs = append(msgs, msg)
+	}
+
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d: %v", len(msgs), msgs)
+	}
+	unknown, ok := msgs[0].(STTUnknownEvent)
+	if !ok {
+		t.Fatalf("expected STTUnknownEvent first, got %T", msgs[0])
+	}
+	if unknown.Type != "speaker_change" {
+		t.Errorf("expected type 'speaker_change', got %q", unknown.Type)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(unknown.Raw, &raw); err != nil {
+		t.Fatalf("failed to decode raw message: %v", err)
+	}
+	if raw["speaker"] != float64(2) {
+		t.Errorf("expected raw speaker 2, got %v", raw["speaker"])
+	}
+	if _, ok := msgs[1].(STTTextResult); !ok {
+		t.Errorf("expected STTTextResult second, got %T", msgs[1])
+	}
+}
+
+func TestSTTStream_UnknownBuffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []STTStreamOption
+		wantTypes []string
+	}{
+		{name: "default buffer", wantTypes: []string{"metrics", "speaker_change", "metrics"}},
+		{name: "small buffer drops overflow", opts: []STTStreamOption{WithSTTUnknownBuffer(1)}, wantTypes: []string{"metrics"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup sttSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]interface{}{"type": "metrics", "rtf": 0.5})
+				conn.WriteJSON(map[string]interface{}{"type": "speaker_change", "speaker": 2})
+				conn.WriteJSON(map[string]interface{}{"type": "metrics", "rtf": 0.4})
+				conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM}, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+			<-stream.Done()
+
+			var types []string
+			for ev := range stream.Unknown() {
+				types = append(types, ev.Type)
+				var raw map[string]interface{}
+				if err := json.Unmarshal(ev.Raw, &raw); err != nil || raw["type"] != ev.Type {
+					t.Errorf("expected raw JSON of a %s message, got %s", ev.Type, ev.Raw)
+				}
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("expected %v, got %v", tt.wantTypes, types)
+			}
+		})
+	}
+}
+
+func TestSTTStream_ReadyInfoOrDefault(t *testing.T) {
+	tests := []struct {
+		name      string
+		readyInfo *STTReadyInfo
+		want      STTReadyInfo
+	}{
+		{
+			name: "not ready",
+			want: STTReadyInfo{SampleRate: 24000, FrameSize: 1920},
+		},
+		{
+			name:      "ready without framing",
+			readyInfo: &STTReadyInfo{RequestID: "req-123"},
+			want:      STTReadyInfo{RequestID: "req-123", SampleRate: 24000, FrameSize: 1920},
+		},
+		{
+			name:      "ready",
+			readyInfo: &STTReadyInfo{RequestID: "req-123", SampleRate: 16000, FrameSize: 1280},
+			want:      STTReadyInfo{RequestID: "req-123", SampleRate: 16000, FrameSize: 1280},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &STTStream{readyInfo: tt.readyInfo}
+			got := stream.readyInfoOrDefault()
+			if got.RequestID != tt.want.RequestID || got.SampleRate != tt.want.SampleRate || got.FrameSize != tt.want.FrameSize {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSTTStream_CollectUtterances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 0.0, "speaker_id": 0})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 0.4})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "there", "start_s": 0.5, "speaker_id": 0})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 0.9})
+		// No end_text: the word ends where the next one starts
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hi", "start_s": 1.0, "speaker_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "again", "start_s": 1.3, "speaker_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "ignored", "start_s": 1.3, "stream_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 1.6})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	utterances, err := stream.CollectUtterances(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	speaker0, speaker1 := 0, 1
+	expected := []Utterance{
+		{
+			Text: "Hello there", StartS: 0.0, StopS: 0.9, SpeakerID: &speaker0,
+			Words: []WordTimestamp{{Text: "Hello", StartS: 0.0, StopS: 0.4}, {Text: "there", StartS: 0.5, StopS: 0.9}},
+		},
+		{
+			Text: "Hi again", StartS: 1.0, StopS: 1.6, SpeakerID: &speaker1,
+			Words: []WordTimestamp{{Text: "Hi", StartS: 1.0, StopS: 1.3}, {Text: "again", StartS: 1.3, StopS: 1.6}},
+		},
+	}
+	if !reflect.DeepEqual(utterances, expected) {
+		t.Errorf("expected %+v, got %+v", expected, utterances)
+	}
+
+	data, err := json.Marshal(utterances[0])
+	if err != nil {
+		t.Fatalf("failed to marshal utterance: %v", err)
+	}
+	want := `{"text":"Hello there","start_s":0,"stop_s":0.9,"speaker_id":0,"words":[{"text":"Hello","start_s":0,"stop_s":0.4},{"text":"there","start_s":0.5,"stop_s":0.9}]}`
+	if string(data) != want {
+		t.Errorf("expected JSON %s, got %s", want, data)
+	}
+}
+
+func TestSTTStream_CollectUtterancesServerWords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]interface{}{
+			"type": "text", "text": "hello world", "start_s": 0.5,
+			"words": []map[string]interface{}{
+				{"text": "hello", "start_s": 0.5, "end_s": 0.9, "confidence": 0.98},
+				{"text": "world", "start_s": 1.0, "end_s": 1.4},
+			},
+		})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 1.6})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "again", "start_s": 2.0})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 2.4})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM, RequestWordTimestamps: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	utterances, err := stream.CollectUtterances(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Utterance{{
+		Text: "hello world again", StartS: 0.5, StopS: 2.4,
+		Words: []WordTimestamp{
+			{Text: "hello", StartS: 0.5, StopS: 0.9, Confidence: float64Ptr(0.98)},
+			{Text: "world", StartS: 1.0, StopS: 1.4},
+			{Text: "again", StartS: 2.0, StopS: 2.4},
+		},
+	}}
+	if !reflect.DeepEqual(utterances, expected) {
+		t.Errorf("expected %+v, got %+v", expected, utterances)
+	}
+}
+
+func TestSTTStream_DialContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		// Stall the handshake until the client gives up
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelExpired()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "cancelled", ctx: cancelled, wantErr: context.Canceled},
+		{name: "deadline exceeded", ctx: expired, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.STT.Stream(tt.ctx, STTParams{InputFormat: InputFormatPCM})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v (%T)", tt.wantErr, err, err)
+			}
+		})
+	}
+}
+
+func TestSTTStream_TextBeforeRangeStart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+
+		// Send text straight after ready, before any audio arrives
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 0.0})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "world", "start_s": 0.5})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if _, err := stream.WaitReady(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Start ranging only after the whole stream has been received
+	<-stream.Done()
+
+	var received []string
+	for result := range stream.Text() {
+		received = append(received, result.Text)
+	}
+	if !reflect.DeepEqual(received, []string{"Hello", "world"}) {
+		t.Errorf("expected [Hello world], got %v", received)
+	}
+}
+
+func TestSTTStream_WordTimestamps(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   bool
+		text      map[string]interface{}
+		wantWords []WordTimestamp
+	}{
+		{
+			name:    "words returned",
+			request: true,
+			text: map[string]interface{}{
+				"type":    "text",
+				"text":    "hello world",
+				"start_s": 0.5,
+				"words": []map[string]interface{}{
+					{"text": "hello", "start_s": 0.5, "end_s": 0.9, "confidence": 0.98},
+					{"text": "world", "start_s": 1.0, "end_s": 1.4, "confidence": 0.91},
+				},
+			},
+			wantWords: []WordTimestamp{
+				{Text: "hello", StartS: 0.5, StopS: 0.9, Confidence: float64Ptr(0.98)},
+				{Text: "world", StartS: 1.0, StopS: 1.4, Confidence: float64Ptr(0.91)},
+			},
+		},
+		{
+			name:    "token level only",
+			request: true,
+			text:    map[string]interface{}{"type": "text", "text": "hello", "start_s": 0.5},
+		},
+		{
+			name: "not requested",
+			text: map[string]interface{}{"type": "text", "text": "hello", "start_s": 0.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupCh := make(chan map[string]interface{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup map[string]interface{}
+				conn.ReadJSON(&setup)
+				setupCh <- setup
+
+				conn.WriteJSON(map[string]interface{}{
+					"type":              "ready",
+					"request_id":        "req-123",
+					"model_name":        "default",
+					"sample_rate":       24000,
+					"frame_size":        1920,
+					"delay_in_tokens":   5,
+					"text_stream_names": []string{"main"},
+				})
+				conn.WriteJSON(tt.text)
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{
+				InputFormat:           InputFormatPCM,
+				RequestWordTimestamps: tt.request,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			setup := <-setupCh
+			if got, ok := setup["request_word_timestamps"]; tt.request != ok || (ok && got != true) {
+				t.Errorf("unexpected request_word_timestamps in setup: %v (present %v)", got, ok)
+			}
+
+			var results []STTTextResult
+			for result := range stream.Text() {
+				results = append(results, result)
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			if !reflect.DeepEqual(results[0].Words, tt.wantWords) {
+				t.Errorf("expected words %+v, got %+v", tt.wantWords, results[0].Words)
+			}
+		})
+	}
+}
+
+func TestSTTStream_CollectDiarizedTranscript(t *testing.T) {
+	setupCh := make(chan sttSetupMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		setupCh <- setup
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Good", "start_s": 0.0, "speaker_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "morning", "start_s": 0.3, "speaker_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 0.8})
+		// An end_text from another stream must not close the pending word
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 1.0, "speaker_id": 2})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 9.9, "stream_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 1.4})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Bye", "start_s": 2.0, "speaker_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 2.3})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+		Diarization: &DiarizationConfig{MinSpeakers: 2, MaxSpeakers: 4},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	setup := <-setupCh
+	if setup.Diarization == nil || *setup.Diarization != (DiarizationConfig{MinSpeakers: 2, MaxSpeakers: 4}) {
+		t.Errorf("expected diarization config in setup, got %+v", setup.Diarization)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	segments, err := stream.CollectDiarizedTranscript(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []SpeakerSegment{
+		{Text: "Good morning", StartS: 0.0, EndS: 0.8, SpeakerID: 1},
+		{Text: "Hello", StartS: 1.0, EndS: 1.4, SpeakerID: 2},
+		{Text: "Bye", StartS: 2.0, EndS: 2.3, SpeakerID: 1},
+	}
+	if !reflect.DeepEqual(segments, expected) {
+		t.Errorf("expected %+v, got %+v", expected, segments)
+	}
+}
+
+func TestSTTStream_Dropped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		for i := 0; i < 3; i++ {
+			conn.WriteJSON(map[string]interface{}{"type": "text", "text": "word", "start_s": float64(i)})
+		}
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithChannelBufferSize(1))
+	client.wsURL = wsURL
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	defer stream.Close()
+
+	<-stream.Done()
+
+	if got := stream.Dropped(); got != 2 {
+		t.Errorf("expected 2 dropped text results, got %d", got)
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestSTTStream_ConfidenceThreshold(t *testing.T) {
+	tests := []struct {
+		name          string
+		threshold     *float64
+		wantText      string
+		wantDiscarded []string
+	}{
+		{
+			name:     "no threshold",
+			wantText: "clear mumble unscored",
+		},
+		{
+			name:          "low confidence discarded",
+			threshold:     float64Ptr(0.5),
+			wantText:      "clear unscored",
+			wantDiscarded: []string{"mumble"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupCh := make(chan map[string]interface{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup map[string]interface{}
+				conn.ReadJSON(&setup)
+				setupCh <- setup
+				conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]interface{}{"type": "text", "text": "clear", "start_s": 0.0, "confidence": 0.9})
+				conn.WriteJSON(map[string]interface{}{"type": "text", "text": "mumble", "start_s": 0.5, "confidence": 0.2})
+				conn.WriteJSON(map[string]interface{}{"type": "text", "text": "unscored", "start_s": 1.0})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{
+				InputFormat:         InputFormatPCM,
+				ConfidenceThreshold: tt.threshold,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			setup := <-setupCh
+			got, ok := setup["confidence_threshold"]
+			if (tt.threshold != nil) != ok || (ok && got != *tt.threshold) {
+				t.Errorf("unexpected confidence_threshold in setup: %v (present %v)", got, ok)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			text, err := stream.CollectText(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text != tt.wantText {
+				t.Errorf("expected text %q, got %q", tt.wantText, text)
+			}
+
+			var discarded []string
+			for result := range stream.DiscardedText() {
+				if result.Confidence == nil {
+					t.Errorf("discarded result %q has no confidence", result.Text)
+				}
+				discarded = append(discarded, result.Text)
+			}
+			if !reflect.DeepEqual(discarded, tt.wantDiscarded) {
+				t.Errorf("expected discarded %q, got %q", tt.wantDiscarded, discarded)
+			}
+		})
+	}
+}
+
+func TestSTTStream_ValidateAudio(t *testing.T) {
+	const frameBytes = 4 * bytesPerSample // frame_size 4 in the ready message
+
+	tests := []struct {
+		name     string
+		validate bool
+		audioLen int
+		wantErr  *AudioValidationError
+		wantSent int
+	}{
+		{name: "disabled sends misaligned audio", validate: false, audioLen: 7, wantSent: 1},
+		{name: "aligned audio", validate: true, audioLen: 2 * frameBytes, wantSent: 1},
+		{name: "misaligned audio", validate: true, audioLen: 7, wantErr: &AudioValidationError{Expected: frameBytes, Got: 7}},
+		{name: "chunk too large", validate: true, audioLen: MaxAudioChunkBytes + frameBytes, wantErr: &AudioValidationError{Expected: MaxAudioChunkBytes, Got: MaxAudioChunkBytes + frameBytes}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentCh := make(chan int, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup map[string]interface{}
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123", "frame_size": 4})
+
+				sent := 0
+				for {
+					var msg wsMessage
+					if err := conn.ReadJSON(&msg); err != nil || msg.Type == "end_of_stream" {
+						break
+					}
+					sent++
+				}
+				sentCh <- sent
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM, ValidateAudio: tt.validate})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := stream.WaitReady(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = stream.SendAudio(make([]byte, tt.audioLen))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else {
+				var audioErr *AudioValidationError
+				if !errors.As(err, &audioErr) {
+					t.Fatalf("expected AudioValidationError, got %v", err)
+				}
+				if audioErr.Expected != tt.wantErr.Expected || audioErr.Got != tt.wantErr.Got {
+					t.Errorf("expected expected=%d got=%d, got %+v", tt.wantErr.Expected, tt.wantErr.Got, audioErr)
+				}
+				if !errors.Is(err, ErrValidation) {
+					t.Error("expected AudioValidationError to match ErrValidation")
+				}
+			}
+
+			stream.SendEndOfStream()
+			if sent := <-sentCh; sent != tt.wantSent {
+				t.Errorf("expected %d audio messages sent, got %d", tt.wantSent, sent)
+			}
+		})
+	}
+}
+
+func TestSTTStream_Hotwords(t *testing.T) {
+	setupCh := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup map[string]interface{}
+		conn.ReadJSON(&setup)
+		setupCh <- setup
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+		Hotwords:    []Hotword{{Word: "Kubernetes", Boost: 7.5}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	setup := <-setupCh
+	want := []interface{}{map[string]interface{}{"word": "Kubernetes", "boost": 7.5}}
+	if !reflect.DeepEqual(setup["hotwords"], want) {
+		t.Errorf("expected hotwords %v in setup, got %v", want, setup["hotwords"])
+	}
+
+	_, err = client.STT.Stream(context.Background(), STTParams{
+		InputFormat: InputFormatPCM,
+		Hotwords:    []Hotword{{Word: "OAuth2", Boost: 11}},
+	})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ValidationError for out-of-range boost, got %v", err)
+	}
+	select {
+	case <-setupCh:
+		t.Error("expected no connection for invalid hotwords")
+	default:
+	}
+}
+
+func TestSTTStream_Language(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       STTParams
+		wantSetup    map[string]interface{}
+		wantDetected *string
+		wantErr      bool
+	}{
+		{
+			name:         "auto detect",
+			params:       STTParams{InputFormat: InputFormatPCM, AutoDetectLanguage: true},
+			wantSetup:    map[string]interface{}{"auto_detect_language": true},
+			wantDetected: stringPtr("fr"),
+		},
+		{
+			name:      "language hint",
+			params:    STTParams{InputFormat: InputFormatPCM, Language: stringPtr("de")},
+			wantSetup: map[string]interface{}{"language": "de"},
+		},
+		{
+			name:    "both set",
+			params:  STTParams{InputFormat: InputFormatPCM, Language: stringPtr("de"), AutoDetectLanguage: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupCh := make(chan map[string]interface{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup map[string]interface{}
+				conn.ReadJSON(&setup)
+				setupCh <- setup
+				ready := map[string]interface{}{"type": "ready", "request_id": "req-123"}
+				if setup["auto_detect_language"] == true {
+					ready["detected_language"] = "fr"
+				}
+				conn.WriteJSON(ready)
+				conn.WriteJSON(map[string]interface{}{"type": "text", "text": "hello", "start_s": 0.0, "language": "en"})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), tt.params)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			setup := <-setupCh
+			for _, key := range []string{"language", "auto_detect_language"} {
+				want, wantOK := tt.wantSetup[key]
+				got, gotOK := setup[key]
+				if wantOK != gotOK || got != want {
+					t.Errorf("expected %s %v (present %v), got %v (present %v)", key, want, wantOK, got, gotOK)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := stream.WaitReady(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := stream.DetectedLanguage(); !reflect.DeepEqual(got, tt.wantDetected) {
+				t.Errorf("expected detected language %v, got %v", tt.wantDetected, got)
+			}
+
+			result := <-stream.Text()
+			if result.Language == nil || *result.Language != "en" {
+				t.Errorf("expected segment language en, got %v", result.Language)
+			}
+		})
+	}
+}
+
+func TestSTTStream_LatencyToFirstToken(t *testing.T) {
+	const delay = 50 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup map[string]interface{}
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+		conn.ReadMessage() // first audio chunk
+		time.Sleep(delay)
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "hello", "start_s": 0.0})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := stream.WaitReady(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stream.LatencyToFirstToken(); got != 0 {
+		t.Errorf("expected 0 before any audio, got %v", got)
+	}
+
+	stream.SendAudio(make([]byte, 3840))
+	<-stream.Text()
+	if got := stream.LatencyToFirstToken(); got < delay {
+		t.Errorf("expected latency of at least %v, got %v", delay, got)
+	}
+}
+
+// newChunkRecordingSTTServer starts an STT server that records the size of
+// each audio chunk it receives and, after end of stream, replies with a
+// single "done" text result.
+func newChunkRecordingSTTServer(t *testing.T, chunkSizes *[]int, mu *sync.Mutex) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{
+			"type":       "ready",
+			"request_id": "req-reader",
+			"frame_size": 960,
+		})
+
+		for {
+			var msg sttAudioMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+			decoded, _ := base64.StdEncoding.DecodeString(msg.Audio)
+			mu.Lock()
+			*chunkSizes = append(*chunkSizes, len(decoded))
+			mu.Unlock()
+		}
+
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "done"})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+}
+
+func TestSTTService_TranscribeReader(t *testing.T) {
+	errRead := errors.New("read failed")
+
+	tests := []struct {
+		name       string
+		reader     io.Reader
+		wantChunks []int
+		wantErr    error
+	}{
+		{
+			name:       "partial last frame",
+			reader:     bytes.NewReader(make([]byte, 1920*5/2)),
+			wantChunks: []int{1920, 1920, 960},
+		},
+		{
+			name:       "whole frames",
+			reader:     bytes.NewReader(make([]byte, 1920*2)),
+			wantChunks: []int{1920, 1920},
+		},
+		{
+			name:       "small reads",
+			reader:     iotest.OneByteReader(bytes.NewReader(make([]byte, 1920+10))),
+			wantChunks: []int{1920, 10},
+		},
+		{
+			name:   "empty",
+			reader: bytes.NewReader(nil),
+		},
+		{
+			name:    "read error",
+			reader:  io.MultiReader(bytes.NewReader(make([]byte, 1920)), iotest.ErrReader(errRead)),
+			wantErr: errRead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunkSizes []int
+			var mu sync.Mutex
+			server := newChunkRecordingSTTServer(t, &chunkSizes, &mu)
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			text, err := client.STT.TranscribeReader(ctx, STTParams{InputFormat: InputFormatPCM}, tt.reader)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TranscribeReader failed: %v", err)
+			}
+			if text != "done" {
+				t.Errorf("expected text %q, got %q", "done", text)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(chunkSizes, tt.wantChunks) {
+				t.Errorf("expected chunks %v, got %v", tt.wantChunks, chunkSizes)
+			}
+		})
+	}
+}
+
+func TestSTTService_StreamReader(t *testing.T) {
+	var chunkSizes []int
+	var mu sync.Mutex
+	server := newChunkRecordingSTTServer(t, &chunkSizes, &mu)
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("success", func(t *testing.T) {
+		stream, err := client.STT.StreamReader(ctx, STTParams{InputFormat: InputFormatPCM}, bytes.NewReader(make([]byte, 1920*3)))
+		if err != nil {
+			t.Fatalf("StreamReader failed: %v", err)
+		}
+		defer stream.Close()
+
+		var texts []string
+		for result := range stream.Text() {
+			texts = append(texts, result.Text)
+		}
+		if !reflect.DeepEqual(texts, []string{"done"}) {
+			t.Errorf("expected [done], got %v", texts)
+		}
+		if err := <-stream.DoneErr(); err != nil {
+			t.Errorf("expected clean end, got %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !reflect.DeepEqual(chunkSizes, []int{1920, 1920, 1920}) {
+			t.Errorf("expected three whole frames, got %v", chunkSizes)
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		errRead := errors.New("read failed")
+		stream, err := client.STT.StreamReader(ctx, STTParams{InputFormat: InputFormatPCM}, iotest.ErrReader(errRead))
+		if err != nil {
+			t.Fatalf("StreamReader failed: %v", err)
+		}
+		defer stream.Close()
+
+		select {
+		case err := <-stream.DoneErr():
+			if !errors.Is(err, errRead) {
+				t.Errorf("expected %v on DoneErr, got %v", errRead, err)
+			}
+		case <-ctx.Done():
+			t.Fatal("stream did not end after read error")
+		}
+	})
+}
+
+func TestSTTStream_CollectSegments(t *testing.T) {
+	speaker1 := 1
+
+	tests := []struct {
+		name     string
+		messages []map[string]interface{}
+		abort    bool
+		expected []STTSegment
+		wantErr  bool
+	}{
+		{
+			name: "text joined until end_text",
+			messages: []map[string]interface{}{
+				{"type": "text", "text": "Hello", "start_s": 0.0},
+				{"type": "text", "text": "there", "start_s": 0.3},
+				{"type": "end_text", "stop_s": 0.6},
+				{"type": "text", "text": "Bye", "start_s": 1.0, "speaker_id": 1},
+				{"type": "end_text", "stop_s": 1.2},
+			},
+			expected: []STTSegment{
+				{Text: "Hello there", StartS: 0.0, EndS: 0.6},
+				{Text: "Bye", StartS: 1.0, EndS: 1.2, SpeakerID: &speaker1},
+			},
+		},
+		{
+			name: "streams are tracked separately",
+			messages: []map[string]interface{}{
+				{"type": "text", "text": "main", "start_s": 0.0},
+				{"type": "text", "text": "other", "start_s": 0.1, "stream_id": 1},
+				{"type": "end_text", "stop_s": 0.5, "stream_id": 1},
+				{"type": "text", "text": "text", "start_s": 0.2, "stream_id": 0},
+				{"type": "end_text", "stop_s": 0.7},
+			},
+			expected: []STTSegment{
+				{Text: "main text", StartS: 0.0, EndS: 0.7},
+				{Text: "other", StartS: 0.1, EndS: 0.5, StreamID: 1},
+			},
+		},
+		{
+			name: "missing end_text",
+			messages: []map[string]interface{}{
+				{"type": "end_text", "stop_s": 0.1},
+				{"type": "text", "text": "Trailing", "start_s": 0.2},
+			},
+			expected: []STTSegment{{Text: "Trailing", StartS: 0.2, EndS: -1}},
+		},
+		{
+			name: "interrupted stream",
+			messages: []map[string]interface{}{
+				{"type": "text", "text": "Done", "start_s": 0.0},
+				{"type": "end_text", "stop_s": 0.2},
+				{"type": "text", "text": "Cut", "start_s": 0.3},
+			},
+			abort: true,
+			expected: []STTSegment{
+				{Text: "Done", StartS: 0.0, EndS: 0.2},
+				{Text: "Cut", StartS: 0.3, EndS: -1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup sttSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+				for _, msg := range tt.messages {
+					conn.WriteJSON(msg)
+				}
+				if !tt.abort {
+					conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+				}
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			segments, err := stream.CollectSegments(ctx)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(segments, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, segments)
+			}
+		})
+	}
+
+	t.Run("context cancelled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := wsUpgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			var setup sttSetupMessage
+			conn.ReadJSON(&setup)
+			conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+			// Keep the stream open until the client goes away
+			conn.ReadMessage()
+		}))
+		defer server.Close()
+
+		client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+		client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+		stream, err := client.STT.Stream(context.Background(), STTParams{InputFormat: InputFormatPCM})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer stream.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := stream.CollectSegments(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
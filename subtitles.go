@@ -0,0 +1,158 @@
+package gradium
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// subtitleLineWidth is the maximum number of characters per subtitle line.
+const subtitleLineWidth = 42
+
+// openCueDurationS is how long, in seconds, a cue whose end is unknown stays
+// on screen when no later segment bounds it.
+const openCueDurationS = 2.0
+
+// FormatSRT renders segments as a SubRip (.srt) subtitle file with
+// HH:MM:SS,mmm timestamps. Cue text is wrapped at 42 characters and segments
+// with blank text are skipped. A segment whose end is unknown (EndS before
+// StartS, as CollectSegments reports for open segments) ends where the next
+// segment starts, or two seconds after its start. It returns an empty string
+// when there is nothing to render.
+func FormatSRT(segments []STTSegment) string {
+	var b strings.Builder
+	n := 0
+	for i, seg := range segments {
+		lines := wrapSubtitle(seg.Text)
+		if len(lines) == 0 {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", n,
+			subtitleTimestamp(seg.StartS, ','), subtitleTimestamp(cueEnd(segments, i), ','),
+			strings.Join(lines, "\n"))
+	}
+	return b.String()
+}
+
+// FormatVTT renders segments as a WebVTT (.vtt) subtitle file with
+// HH:MM:SS.mmm timestamps. Segments with a SpeakerID are tagged with a
+// "Speaker N" voice span. Cue text is wrapped at 42 characters and segments
+// with blank text are skipped. Unknown ends are filled in as by FormatSRT.
+// It returns an empty string when there is nothing to render.
+func FormatVTT(segments []STTSegment) string {
+	var b strings.Builder
+	for i, seg := range segments {
+		lines := wrapSubtitle(seg.Text)
+		if len(lines) == 0 {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString("WEBVTT\n\n")
+		}
+		if seg.SpeakerID != nil {
+			lines[0] = fmt.Sprintf("<v Speaker %d>%s", *seg.SpeakerID, lines[0])
+		}
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n",
+			subtitleTimestamp(seg.StartS, '.'), subtitleTimestamp(cueEnd(segments, i), '.'),
+			strings.Join(lines, "\n"))
+	}
+	return b.String()
+}
+
+// TranscribeToSRT transcribes complete audio data and returns it as a SubRip
+// subtitle file, with one cue per utterance as grouped by
+// STTStream.CollectUtterances.
+//
+// Example:
+//
+//	srt, err := gradium.TranscribeToSRT(ctx, client, gradium.STTParams{
+//	    InputFormat: gradium.InputFormatWAV,
+//	}, audioData)
+//	os.WriteFile("subtitles.srt", []byte(srt), 0644)
+func TranscribeToSRT(ctx context.Context, client *Client, params STTParams, audioData []byte) (string, error) {
+	ctx, cancel := withDefaultTimeout(ctx, client.STT.timeout)
+	defer cancel()
+
+	stream, err := client.STT.Stream(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stream.Close() }()
+
+	if _, err := stream.WaitReady(ctx); err != nil {
+		return "", err
+	}
+
+	if err := stream.sendAllAudio(audioData); err != nil {
+		return "", err
+	}
+
+	utterances, err := stream.CollectUtterances(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	segments := make([]STTSegment, len(utterances))
+	for i, u := range utterances {
+		segments[i] = STTSegment{Text: u.Text, StartS: u.StartS, EndS: u.StopS, SpeakerID: u.SpeakerID}
+	}
+	return FormatSRT(segments), nil
+}
+
+// cueEnd returns the end of segments[i]. If EndS is before StartS the end is
+// unknown, and the cue runs until the next segment that starts later, capped
+// at openCueDurationS.
+func cueEnd(segments []STTSegment, i int) float64 {
+	seg := segments[i]
+	if seg.EndS >= seg.StartS {
+		return seg.EndS
+	}
+	end := seg.StartS + openCueDurationS
+	for _, next := range segments[i+1:] {
+		if next.StartS > seg.StartS {
+			return math.Min(next.StartS, end)
+		}
+	}
+	return end
+}
+
+// subtitleTimestamp formats seconds as HH:MM:SS followed by sep and
+// milliseconds, rounding to the nearest millisecond.
+func subtitleTimestamp(seconds float64, sep byte) string {
+	ms := int64(math.Round(seconds * 1000))
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d",
+		ms/3_600_000, ms/60_000%60, ms/1000%60, sep, ms%1000)
+}
+
+// wrapSubtitle splits text into lines of at most subtitleLineWidth
+// characters, breaking between words. A word longer than the width gets a
+// line of its own.
+func wrapSubtitle(text string) []string {
+	var lines []string
+	var line strings.Builder
+	width := 0
+	for _, word := range strings.Fields(text) {
+		n := utf8.RuneCountInString(word)
+		if width > 0 && width+1+n > subtitleLineWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+			width = 0
+		}
+		if width > 0 {
+			line.WriteByte(' ')
+			width++
+		}
+		line.WriteString(word)
+		width += n
+	}
+	if width > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
@@ -0,0 +1,166 @@
+package gradium
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatSRT(t *testing.T) {
+	speaker := 1
+	tests := []struct {
+		name     string
+		segments []STTSegment
+		want     string
+	}{
+		{
+			name: "empty transcript",
+			want: "",
+		},
+		{
+			name:     "blank segments only",
+			segments: []STTSegment{{Text: "  ", StartS: 0, EndS: 1}},
+			want:     "",
+		},
+		{
+			name: "numbered cues",
+			segments: []STTSegment{
+				{Text: "Hello there.", StartS: 0, EndS: 1.5},
+				{Text: "", StartS: 1.5, EndS: 2},
+				{Text: "General Kenobi!", StartS: 3661.25, EndS: 3662, SpeakerID: &speaker},
+			},
+			want: "1\n00:00:00,000 --> 00:00:01,500\nHello there.\n\n" +
+				"2\n01:01:01,250 --> 01:01:02,000\nGeneral Kenobi!\n\n",
+		},
+		{
+			name:     "sub-millisecond precision rounds",
+			segments: []STTSegment{{Text: "Hi", StartS: 0.0005, EndS: 1.9996}},
+			want:     "1\n00:00:00,001 --> 00:00:02,000\nHi\n\n",
+		},
+		{
+			name: "unknown end",
+			segments: []STTSegment{
+				{Text: "hello", StartS: 5, EndS: -1},
+				{Text: "world", StartS: 6, EndS: -1},
+				{Text: "again", StartS: 10, EndS: -1},
+			},
+			want: "1\n00:00:05,000 --> 00:00:06,000\nhello\n\n" +
+				"2\n00:00:06,000 --> 00:00:08,000\nworld\n\n" +
+				"3\n00:00:10,000 --> 00:00:12,000\nagain\n\n",
+		},
+		{
+			name: "long text wraps at 42 characters",
+			segments: []STTSegment{{
+				Text:   "The quick brown fox jumps over the lazy dog and keeps on running",
+				StartS: 0, EndS: 4,
+			}},
+			want: "1\n00:00:00,000 --> 00:00:04,000\n" +
+				"The quick brown fox jumps over the lazy\ndog and keeps on running\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSRT(tt.segments); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatVTT(t *testing.T) {
+	speaker := 2
+	tests := []struct {
+		name     string
+		segments []STTSegment
+		want     string
+	}{
+		{
+			name: "empty transcript",
+			want: "",
+		},
+		{
+			name: "speaker voice spans",
+			segments: []STTSegment{
+				{Text: "Hello there.", StartS: 0, EndS: 1.5},
+				{Text: "General Kenobi!", StartS: 61.0004, EndS: 62.1236, SpeakerID: &speaker},
+			},
+			want: "WEBVTT\n\n" +
+				"00:00:00.000 --> 00:00:01.500\nHello there.\n\n" +
+				"00:01:01.000 --> 00:01:02.124\n<v Speaker 2>General Kenobi!\n\n",
+		},
+		{
+			name:     "unknown end",
+			segments: []STTSegment{{Text: "hello", StartS: 5, EndS: -1}},
+			want:     "WEBVTT\n\n00:00:05.000 --> 00:00:07.000\nhello\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatVTT(tt.segments); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWrapSubtitle(t *testing.T) {
+	long := strings.Repeat("x", 50)
+	got := wrapSubtitle("short " + long + " tail")
+	want := []string{"short", long, "tail"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestTranscribeToSRT(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup sttSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]interface{}{"type": "ready", "request_id": "req-123"})
+
+		for {
+			var msg map[string]interface{}
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg["type"] == "end_of_stream" {
+				break
+			}
+		}
+
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hello", "start_s": 0.0, "speaker_id": 0})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 0.4})
+		conn.WriteJSON(map[string]interface{}{"type": "text", "text": "Hi", "start_s": 1.0, "speaker_id": 1})
+		conn.WriteJSON(map[string]interface{}{"type": "end_text", "stop_s": 1.3})
+		conn.WriteJSON(map[string]interface{}{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srt, err := TranscribeToSRT(ctx, client, STTParams{InputFormat: InputFormatPCM}, make([]byte, 4800))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "1\n00:00:00,000 --> 00:00:00,400\nHello\n\n" +
+		"2\n00:00:01,000 --> 00:00:01,300\nHi\n\n"
+	if srt != want {
+		t.Errorf("expected %q, got %q", want, srt)
+	}
+}
@@ -0,0 +1,76 @@
+package gradium
+
+import "context"
+
+// Span names reported to a StreamTracer.
+const (
+	// SpanTTSAudio covers a TTS stream from dialing until its Audio channel
+	// closes.
+	SpanTTSAudio = "gradium.tts.audio"
+	// SpanTTSWaitReady covers a TTSStream.WaitReady call.
+	SpanTTSWaitReady = "gradium.tts.wait_ready"
+	// SpanSTTStream covers an STT stream from dialing until it ends.
+	SpanSTTStream = "gradium.stt.stream"
+	// SpanSTTWaitReady covers an STTStream.WaitReady call.
+	SpanSTTWaitReady = "gradium.stt.wait_ready"
+	// SpanSTTCollectText covers an STTStream.CollectText call.
+	SpanSTTCollectText = "gradium.stt.collect_text"
+)
+
+// Span attribute keys reported to a StreamTracer.
+const (
+	AttrVoiceID               = "voice_id"
+	AttrOutputFormat          = "output_format"
+	AttrModelName             = "model_name"
+	AttrRequestID             = "request_id"
+	AttrAudioBytesTransferred = "audio_bytes_transferred"
+)
+
+// StreamTracer creates spans around WebSocket stream operations. It is
+// independent of any tracing library so the SDK carries no tracing
+// dependency; the gradiumotel package implements it for OpenTelemetry.
+type StreamTracer interface {
+	StartSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, StreamSpan)
+}
+
+// StreamSpan is a span started by a StreamTracer.
+type StreamSpan interface {
+	SetAttributes(attrs ...SpanAttribute)
+	// End finishes the span, recording err if it is not nil.
+	End(err error)
+}
+
+// SpanAttribute is a key-value pair attached to a span. Value is a string or
+// an int64.
+type SpanAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+// WithStreamTracer reports TTS and STT stream operations to t. Use
+// gradiumotel.WithTracer to trace with OpenTelemetry.
+func WithStreamTracer(t StreamTracer) ClientOption {
+	return func(c *Client) {
+		c.streamTracer = t
+	}
+}
+
+// tracer returns the client's StreamTracer, or one that does nothing.
+func (c *Client) tracer() StreamTracer {
+	if c.streamTracer == nil {
+		return noopTracer{}
+	}
+	return c.streamTracer
+}
+
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ string, _ ...SpanAttribute) (context.Context, StreamSpan) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...SpanAttribute) {}
+
+func (noopSpan) End(error) {}
@@ -4,30 +4,236 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gorilla/websocket"
 )
 
+// defaultMaxTextChunkLen is the default maximum size in bytes of a single
+// text message sent by TTSService.Create.
+const defaultMaxTextChunkLen = 4096
+
+// unknownChannelSize is the default buffer size of TTSStream.Unknown and
+// STTStream.Unknown.
+const unknownChannelSize = 10
+
+// TTSTextSplitter splits text into chunks of at most maxLen bytes. A maxLen
+// of zero or less means no limit.
+// Implement it to plug a custom sentence segmenter into TTSService.Create.
+type TTSTextSplitter interface {
+	Split(text string, maxLen int) []string
+}
+
+// SentenceSplitter is the default TTSTextSplitter. It breaks text after
+// sentence-ending punctuation (. ! ?) followed by whitespace and packs as many
+// sentences as fit into each chunk. Common abbreviations ("Dr.", "e.g."),
+// initials and ellipses do not end a sentence. Sentences longer than the
+// limit are split at the last whitespace not preceded by an ellipsis, or at
+// a rune boundary if a single word exceeds the limit.
+// Concatenating the chunks yields the original text.
+type SentenceSplitter struct{}
+
+// Split implements TTSTextSplitter.
+func (SentenceSplitter) Split(text string, maxLen int) []string {
+	if maxLen <= 0 || len(text) <= maxLen {
+		return []string{text}
+	}
+
+	var chunks []string
+	current := ""
+	for _, sentence := range splitSentences(text) {
+		if len(current)+len(sentence) <= maxLen {
+			current += sentence
+			continue
+		}
+		if current != "" {
+			chunks = append(chunks, current)
+			current = ""
+		}
+		for len(sentence) > maxLen {
+			cut := splitPoint(sentence, maxLen)
+			chunks = append(chunks, sentence[:cut])
+			sentence = sentence[cut:]
+		}
+		current = sentence
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// splitSentences breaks text after sentence-ending punctuation, keeping the
+// following whitespace attached to the preceding sentence.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	endOfSentence, sawSpace := false, false
+
+	for i, r := range text {
+		switch {
+		case r == '.' || r == '!' || r == '?':
+			endOfSentence, sawSpace = true, false
+		case unicode.IsSpace(r):
+			sawSpace = true
+		default:
+			if endOfSentence && sawSpace && !continuesSentence(text[start:i], text[i:]) {
+				sentences = append(sentences, text[start:i])
+				start = i
+			}
+			endOfSentence, sawSpace = false, false
+		}
+	}
+
+	return append(sentences, text[start:])
+}
+
+// abbreviations lists lower-case abbreviations whose trailing period does
+// not end a sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true,
+	"sr": true, "jr": true, "st": true, "vs": true, "etc": true,
+	"e.g": true, "i.e": true, "inc": true, "ltd": true, "fig": true,
+	"approx": true, "mt": true,
+}
+
+// continuesSentence reports whether sentence, which ends in punctuation and
+// whitespace, stops at an ellipsis, an abbreviation or an initial rather
+// than at the end of a sentence. next is the text that follows it.
+func continuesSentence(sentence, next string) bool {
+	sentence = strings.TrimRightFunc(sentence, unicode.IsSpace)
+	if endsWithEllipsis(sentence) {
+		return true
+	}
+	if !strings.HasSuffix(sentence, ".") {
+		return false
+	}
+
+	wordStart := strings.LastIndexFunc(sentence, unicode.IsSpace) + 1
+	word := strings.TrimSuffix(strings.TrimLeft(sentence[wordStart:], "(\"'"), ".")
+	if utf8.RuneCountInString(word) == 1 {
+		r, _ := utf8.DecodeRuneInString(word)
+		return unicode.IsUpper(r) // An initial, as in "J. R. R. Tolkien"
+	}
+
+	// "no" and "co" are also ordinary words, so they only count as
+	// abbreviations in the contexts where they are used as one.
+	switch strings.ToLower(word) {
+	case "no": // "No. 5"
+		r, _ := utf8.DecodeRuneInString(next)
+		return unicode.IsDigit(r)
+	case "co": // "Smith & Co.", "Acme Co."
+		before := strings.TrimRightFunc(sentence[:wordStart], unicode.IsSpace)
+		prev := before[strings.LastIndexFunc(before, unicode.IsSpace)+1:]
+		r, _ := utf8.DecodeRuneInString(prev)
+		return prev == "&" || unicode.IsUpper(r)
+	}
+	return abbreviations[strings.ToLower(word)]
+}
+
+// endsWithEllipsis reports whether s ends with "..." or "…".
+func endsWithEllipsis(s string) bool {
+	return strings.HasSuffix(s, "...") || strings.HasSuffix(s, "…")
+}
+
+// splitPoint returns the byte index at which to cut s so that s[:i] is at most
+// maxLen bytes, preferring the position after the last whitespace.
+func splitPoint(s string, maxLen int) int {
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		// A single rune wider than maxLen; emit it whole.
+		_, size := utf8.DecodeRuneInString(s)
+		return size
+	}
+
+	for i := cut; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsSpace(r) && !endsWithEllipsis(strings.TrimRightFunc(s[:i], unicode.IsSpace)) {
+			return i
+		}
+		i -= size
+	}
+
+	return cut
+}
+
 // TTSService handles text-to-speech operations.
 type TTSService struct {
-	client *Client
+	client  *Client
+	timeout time.Duration
 }
 
 // TTSStream handles streaming TTS responses.
 type TTSStream struct {
-	conn      *websocket.Conn
-	requestID string
-	ready     chan struct{}
-	done      chan struct{}
-	err       error
-	errMu     sync.RWMutex
-	audioCh   chan []byte
-	closeOnce sync.Once
+	conn        atomic.Pointer[websocket.Conn]
+	format      OutputFormat
+	isSSML      bool
+	sessionID   string
+	requestID   string
+	requestIDMu sync.RWMutex
+	ready       chan struct{}
+	done        chan struct{}
+	doneErr     chan error
+	err         error
+	errMu       sync.RWMutex
+	audioCh     chan []byte
+	unknownCh   chan TTSUnknownEvent
+	closeOnce   sync.Once
+	closing     chan struct{}
+
+	// Auto-reconnect state. connMu serialises writes with the replay after a
+	// reconnect and guards unconfirmed and endSent.
+	client      *Client
+	ctx         context.Context
+	setupMsg    ttsSetupMessage
+	reconnect   ttsReconnectConfig
+	connMu      sync.Mutex
+	unconfirmed []ttsTextMessage
+	lastReqID   int
+	endSent     bool
+	reconnectCh chan struct{}
+
+	// pipelined is set once Pipeline has been called.
+	pipelined atomic.Bool
+
+	tracer     StreamTracer
+	span       StreamSpan
+	logger     *slog.Logger
+	audioBytes atomic.Int64
+	dropped    atomic.Int64
+
+	// startedAt is when Stream was called.
+	startedAt time.Time
+	// readyAt is the Unix time in nanoseconds at which the server first
+	// reported ready, or 0 if it has not yet.
+	readyAt atomic.Int64
+	// firstAudioAt is the Unix time in nanoseconds at which the first audio
+	// chunk was received, or 0 if none has arrived yet.
+	firstAudioAt atomic.Int64
 }
 
 // Create converts text to speech and returns the complete audio.
+// If the stream ends without any audio, a ValidationError is returned when
+// params.Text is empty and an EmptyResponseError otherwise.
+// Long texts are split into chunks with the client's TTSTextSplitter (see
+// WithTTSTextSplitter) and sent as successive text messages on one stream;
+// params.MaxChunkBytes overrides the client's chunk size for one call. SSML
+// text (see TTSParams.IsSSML) is validated and sent unsplit.
 //
 // Example:
 //
@@ -38,6 +244,15 @@ type TTSStream struct {
 //	})
 //	os.WriteFile("output.wav", result.RawData, 0644)
 func (s *TTSService) Create(ctx context.Context, params TTSParams) (*TTSResult, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.IsSSML {
+		if err := ValidateSSML(params.Text); err != nil {
+			return nil, err
+		}
+	}
+
 	stream, err := s.Stream(ctx, params)
 	if err != nil {
 		return nil, err
@@ -48,18 +263,81 @@ func (s *TTSService) Create(ctx context.Context, params TTSParams) (*TTSResult,
 		return nil, err
 	}
 
-	if err := stream.SendText(params.Text); err != nil {
-		return nil, err
+	maxLen := s.client.maxTextChunkLen
+	if params.MaxChunkBytes != 0 {
+		maxLen = params.MaxChunkBytes
+	}
+	chunks := s.client.textSplitter.Split(params.Text, maxLen)
+	if params.IsSSML && params.Text != "" {
+		chunks = []string{params.Text}
+	}
+	for _, chunk := range chunks {
+		if err := stream.SendText(chunk); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := stream.SendEndOfStream(); err != nil {
 		return nil, err
 	}
 
-	return stream.Collect(ctx)
+	result, err := stream.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.RawData) == 0 {
+		if params.Text == "" {
+			return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "text is required for TTS", Loc: []interface{}{"text"}}}}
+		}
+		return nil, &EmptyResponseError{Message: "no audio received"}
+	}
+
+	return result, nil
+}
+
+// TTSStreamOption configures a TTSStream at creation time.
+type TTSStreamOption func(*ttsStreamConfig)
+
+type ttsStreamConfig struct {
+	reconnect  ttsReconnectConfig
+	unknownBuf int
+}
+
+type ttsReconnectConfig struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// WithAutoReconnect makes the stream re-dial up to maxAttempts times, waiting
+// backoff before each attempt, when its connection drops. After reconnecting
+// it resends the setup message and replays the text not yet confirmed, along
+// with the end of stream if it was sent. Audio keeps flowing on the same
+// Audio channel, and Reconnects fires each time.
+//
+// Each text message is sent with a client_req_id, and a message counts as
+// confirmed once audio carrying its ID, or the ID of a later message,
+// arrives. Audio without an ID confirms nothing, so against a server that
+// does not echo the ID all text is replayed.
+//
+// Error messages from the server are not retried, and Done only closes once
+// the attempts are exhausted or the context passed to Stream is done.
+func WithAutoReconnect(maxAttempts int, backoff time.Duration) TTSStreamOption {
+	return func(c *ttsStreamConfig) {
+		c.reconnect = ttsReconnectConfig{maxAttempts: maxAttempts, backoff: backoff}
+	}
+}
+
+// WithTTSUnknownBuffer sets the capacity of the stream's Unknown channel.
+// The default is 10.
+func WithTTSUnknownBuffer(n int) TTSStreamOption {
+	return func(c *ttsStreamConfig) {
+		c.unknownBuf = n
+	}
 }
 
 // Stream creates a streaming TTS connection.
+// If ctx is cancelled or expires while dialing, ctx.Err() is returned instead
+// of a ConnectionError.
 //
 // Example:
 //
@@ -76,47 +354,78 @@ func (s *TTSService) Create(ctx context.Context, params TTSParams) (*TTSResult,
 //	for chunk := range stream.Audio() {
 //	    // Process audio chunk
 //	}
-func (s *TTSService) Stream(ctx context.Context, params TTSParams) (*TTSStream, error) {
-	wsURL := s.client.wsURL + "/tts"
+func (s *TTSService) Stream(ctx context.Context, params TTSParams, opts ...TTSStreamOption) (*TTSStream, error) {
+	startedAt := time.Now()
+	config := ttsStreamConfig{unknownBuf: unknownChannelSize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	modelName := resolveModelName(params.ModelName, s.client.ttsModel)
+
+	var jsonConfig map[string]interface{}
+	if params.JSONConfig != nil {
+		if err := params.JSONConfig.validate(); err != nil {
+			return nil, err
+		}
+		var err error
+		if jsonConfig, err = params.JSONConfig.setupConfig(); err != nil {
+			return nil, err
+		}
+	}
 
-	header := http.Header{}
-	header.Set("x-api-key", s.client.apiKey)
+	tracer := s.client.tracer()
+	ctx, span := tracer.StartSpan(ctx, SpanTTSAudio,
+		SpanAttribute{Key: AttrVoiceID, Value: params.VoiceID},
+		SpanAttribute{Key: AttrOutputFormat, Value: string(params.OutputFormat)},
+		SpanAttribute{Key: AttrModelName, Value: modelName},
+	)
 
-	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	conn, resp, err := s.client.dialTTS(ctx)
 	if err != nil {
-		return nil, &ConnectionError{Message: "failed to connect to TTS WebSocket: " + err.Error()}
+		span.End(err)
+		return nil, err
 	}
 
 	stream := &TTSStream{
-		conn:    conn,
-		ready:   make(chan struct{}),
-		done:    make(chan struct{}),
-		audioCh: make(chan []byte, 100),
+		format:      params.OutputFormat,
+		isSSML:      params.IsSSML,
+		sessionID:   resp.Header.Get("x-request-id"),
+		ready:       make(chan struct{}),
+		done:        make(chan struct{}),
+		doneErr:     make(chan error, 1),
+		audioCh:     make(chan []byte, s.client.ttsAudioChannelSize),
+		unknownCh:   make(chan TTSUnknownEvent, max(config.unknownBuf, 0)),
+		client:      s.client,
+		ctx:         ctx,
+		reconnect:   config.reconnect,
+		reconnectCh: make(chan struct{}, 1),
+		closing:     make(chan struct{}),
+		tracer:      tracer,
+		span:        span,
+		logger:      s.client.log().With(logKeyService, serviceTTS),
+		startedAt:   startedAt,
 	}
 
 	// Send setup message
-	modelName := params.ModelName
-	if modelName == "" {
-		modelName = modelNameDefault
-	}
-
 	setupMsg := ttsSetupMessage{
 		Type:         "setup",
 		VoiceID:      params.VoiceID,
 		OutputFormat: params.OutputFormat,
 		ModelName:    modelName,
-	}
-
-	if params.JSONConfig != nil {
-		setupMsg.JSONConfig = map[string]interface{}{
-			"padding_bonus": params.JSONConfig.PaddingBonus,
-		}
+		Language:     params.Language,
+		Locale:       params.Locale,
+		JSONConfig:   jsonConfig,
 	}
 
 	if err := conn.WriteJSON(setupMsg); err != nil {
 		_ = conn.Close()
-		return nil, &WebSocketError{Message: "failed to send setup message: " + err.Error()}
+		err = &WebSocketError{Message: "failed to send setup message: " + err.Error()}
+		span.End(err)
+		return nil, err
 	}
+	stream.setupMsg = setupMsg
+	stream.conn.Store(conn)
 
 	// Start message handler
 	go stream.handleMessages()
@@ -124,15 +433,64 @@ func (s *TTSService) Stream(ctx context.Context, params TTSParams) (*TTSStream,
 	return stream, nil
 }
 
+// dialTTS opens a TTS WebSocket connection.
+func (c *Client) dialTTS(ctx context.Context) (*websocket.Conn, *http.Response, error) {
+	return c.dialWebSocket(ctx, serviceTTS, "/tts")
+}
+
+// dialContextError returns the context error if a WebSocket dial failed
+// because ctx was cancelled or expired, or nil otherwise. The dialer applies
+// the ctx deadline to the connection, so an expired ctx may surface as a
+// network timeout before ctx itself reports it.
+func dialContextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if deadline, ok := ctx.Deadline(); ok && errors.Is(err, os.ErrDeadlineExceeded) && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// setupConfig returns the json_config sent in the setup message: the Extra
+// keys overlaid with the typed fields, as encoded by their JSON tags. Typed
+// fields left at their zero value are omitted.
+func (c *TTSConfig) setupConfig() (map[string]interface{}, error) {
+	typed, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]interface{}, len(c.Extra)+4)
+	for k, v := range c.Extra {
+		config[k] = v
+	}
+	if err := json.Unmarshal(typed, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func (s *TTSStream) handleMessages() {
+	defer s.signalDoneErr()
+	defer func() {
+		s.span.SetAttributes(SpanAttribute{Key: AttrAudioBytesTransferred, Value: s.audioBytes.Load()})
+		s.span.End(s.getError())
+		logStreamComplete(s.logger, s.audioBytes.Load(), s.getError())
+	}()
 	defer close(s.done)
 	defer close(s.audioCh)
+	defer close(s.unknownCh)
+	defer close(s.reconnectCh)
 
 	readySignaled := false
 
 	for {
-		_, data, err := s.conn.ReadMessage()
+		_, data, err := s.conn.Load().ReadMessage()
 		if err != nil {
+			if s.reconnectConn() {
+				continue
+			}
 			s.setError(&WebSocketError{Message: "read error: " + err.Error()})
 			if !readySignaled {
 				close(s.ready)
@@ -149,8 +507,13 @@ func (s *TTSStream) handleMessages() {
 		case msgTypeReady:
 			var readyMsg ttsReadyMessage
 			_ = json.Unmarshal(data, &readyMsg)
+			s.requestIDMu.Lock()
 			s.requestID = readyMsg.RequestID
+			s.requestIDMu.Unlock()
+			s.span.SetAttributes(SpanAttribute{Key: AttrRequestID, Value: readyMsg.RequestID})
+			s.logger.Info(logEventReady, AttrRequestID, readyMsg.RequestID)
 			if !readySignaled {
+				s.readyAt.Store(time.Now().UnixNano())
 				close(s.ready)
 				readySignaled = true
 			}
@@ -164,10 +527,15 @@ func (s *TTSStream) handleMessages() {
 			if err != nil {
 				continue
 			}
-			select {
-			case s.audioCh <- decoded:
-			default:
-				// Channel full, drop audio
+			if s.firstAudioAt.Load() == 0 {
+				s.firstAudioAt.Store(time.Now().UnixNano())
+				s.logger.Info(logEventFirstChunk, logKeyBytes, len(decoded))
+			}
+			s.logger.Debug(logEventAudioChunk, logKeyBytes, len(decoded))
+			s.confirmText(audioMsg.ClientReqID)
+			s.audioBytes.Add(int64(len(decoded)))
+			if !trySend(s.audioCh, decoded, &s.dropped) {
+				logDropped(s.logger, "audio")
 			}
 
 		case msgTypeEndOfStream:
@@ -181,6 +549,13 @@ func (s *TTSStream) handleMessages() {
 				close(s.ready)
 			}
 			return
+
+		default:
+			s.logger.Debug(logEventUnknownMessage, logKeyType, msg.Type)
+			select {
+			case s.unknownCh <- TTSUnknownEvent{Type: msg.Type, Raw: data}:
+			default:
+			}
 		}
 	}
 }
@@ -200,7 +575,11 @@ func (s *TTSStream) getError() error {
 }
 
 // WaitReady waits for the stream to be ready.
-func (s *TTSStream) WaitReady(ctx context.Context) error {
+// Once the stream is ready, further calls return immediately.
+func (s *TTSStream) WaitReady(ctx context.Context) (err error) {
+	_, span := s.tracer.StartSpan(ctx, SpanTTSWaitReady)
+	defer func() { span.End(err) }()
+
 	select {
 	case <-s.ready:
 		return s.getError()
@@ -209,22 +588,156 @@ func (s *TTSStream) WaitReady(ctx context.Context) error {
 	}
 }
 
-// SendText sends text to be converted to speech.
+// SendText sends text to be converted to speech. With WithAutoReconnect, a
+// send that fails because the connection dropped returns nil and the text is
+// replayed once the stream reconnects. If the stream was created with
+// TTSParams.IsSSML, malformed SSML returns a ValidationError without sending
+// anything.
 func (s *TTSStream) SendText(text string) error {
+	if s.isSSML {
+		if err := ValidateSSML(text); err != nil {
+			return err
+		}
+	}
+
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
 	msg := ttsTextMessage{Type: "text", Text: text}
-	return s.conn.WriteJSON(msg)
+	if s.reconnect.maxAttempts > 0 {
+		s.lastReqID++
+		msg.ClientReqID = strconv.Itoa(s.lastReqID)
+		s.unconfirmed = append(s.unconfirmed, msg)
+	}
+	return s.sendLocked(msg)
 }
 
 // SendEndOfStream signals the end of input.
 func (s *TTSStream) SendEndOfStream() error {
-	return s.conn.WriteJSON(wsMessage{Type: msgTypeEndOfStream})
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	s.endSent = true
+	return s.sendLocked(wsMessage{Type: msgTypeEndOfStream})
+}
+
+// sendLocked writes msg to the connection. With auto-reconnect enabled, write
+// errors are swallowed since the message will be replayed. s.connMu must be
+// held.
+func (s *TTSStream) sendLocked(msg interface{}) error {
+	err := s.conn.Load().WriteJSON(msg)
+	if err != nil && s.reconnect.maxAttempts > 0 && !s.isClosed() {
+		return nil
+	}
+	return err
+}
+
+// confirmText marks the text message with client request ID reqID, and every
+// message sent before it, as synthesised, so they are not replayed after a
+// reconnect.
+func (s *TTSStream) confirmText(reqID string) {
+	if s.reconnect.maxAttempts == 0 || reqID == "" {
+		return
+	}
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	for i, msg := range s.unconfirmed {
+		if msg.ClientReqID == reqID {
+			s.unconfirmed = s.unconfirmed[i+1:]
+			return
+		}
+	}
+}
+
+// reconnectConn re-dials after the connection dropped, resends the setup
+// message and replays unconfirmed text. It reports whether the stream is
+// connected again; it gives up once the attempts are exhausted, the stream
+// is closed or the Stream context is done.
+func (s *TTSStream) reconnectConn() bool {
+	for attempt := 0; attempt < s.reconnect.maxAttempts; attempt++ {
+		timer := time.NewTimer(s.reconnect.backoff)
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			timer.Stop()
+			return false
+		case <-s.closing:
+			timer.Stop()
+			return false
+		}
+
+		conn, _, err := s.client.dialTTS(s.ctx)
+		if err != nil {
+			continue
+		}
+		if s.resume(conn) {
+			select {
+			case s.reconnectCh <- struct{}{}:
+			default:
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// resume replays the stream's input on conn and swaps it in. It reports
+// false, closing conn, if a write fails or the stream was closed meanwhile.
+func (s *TTSStream) resume(conn *websocket.Conn) bool {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	msgs := []interface{}{s.setupMsg}
+	for _, msg := range s.unconfirmed {
+		msgs = append(msgs, msg)
+	}
+	if s.endSent {
+		msgs = append(msgs, wsMessage{Type: msgTypeEndOfStream})
+	}
+	for _, msg := range msgs {
+		if err := conn.WriteJSON(msg); err != nil {
+			_ = conn.Close()
+			return false
+		}
+	}
+
+	_ = s.conn.Swap(conn).Close()
+	// Close may have run before the swap and closed the old connection only.
+	if s.isClosed() {
+		_ = conn.Close()
+		return false
+	}
+	return true
 }
 
-// Audio returns a channel that receives audio chunks.
+// Audio returns a channel that receives audio chunks. The channel is closed
+// when the stream ends and may close without delivering any chunk, for
+// example when no text was sent. Use DoneErr to learn why the stream ended.
+//
+// Chunks are buffered from the moment the stream is created, so none are
+// lost if the server sends audio before the caller starts reading. Only
+// chunks beyond the buffer size (see WithTTSAudioChannelSize) are dropped;
+// Dropped counts them.
 func (s *TTSStream) Audio() <-chan []byte {
 	return s.audioCh
 }
 
+// Dropped returns the number of audio chunks discarded so far because the
+// Audio channel was full. Raise the buffer size with WithTTSAudioChannelSize
+// or WithChannelBufferSize if it is not zero. With WithLogger, each drop is
+// also logged at WARN.
+func (s *TTSStream) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Unknown returns a channel that receives messages whose type the SDK does
+// not recognise, such as ones added to the server after this release. Events
+// are dropped when the channel is full, so leaving it unread is harmless;
+// size it with WithTTSUnknownBuffer.
+func (s *TTSStream) Unknown() <-chan TTSUnknownEvent {
+	return s.unknownCh
+}
+
 // Collect waits for all audio and returns the complete result.
 func (s *TTSStream) Collect(ctx context.Context) (*TTSResult, error) {
 	var chunks [][]byte
@@ -246,11 +759,7 @@ func (s *TTSStream) Collect(ctx context.Context) (*TTSResult, error) {
 					offset += len(c)
 				}
 
-				return &TTSResult{
-					RawData:    rawData,
-					SampleRate: 48000,
-					RequestID:  s.requestID,
-				}, nil
+				return newTTSResult(rawData, s.format, s.RequestID()), nil
 			}
 			chunks = append(chunks, chunk)
 			totalLen += len(chunk)
@@ -261,21 +770,340 @@ func (s *TTSStream) Collect(ctx context.Context) (*TTSResult, error) {
 	}
 }
 
-// RequestID returns the request ID.
+// newTTSResult returns a TTSResult describing rawData, audio in format.
+func newTTSResult(rawData []byte, format OutputFormat, requestID string) *TTSResult {
+	return &TTSResult{
+		RawData:    rawData,
+		SampleRate: format.SampleRate(),
+		Channels:   1,
+		Format:     format,
+		RequestID:  requestID,
+	}
+}
+
+// WriteTo writes each audio chunk to w as it arrives and returns the number
+// of bytes written once the stream ends, so audio can be piped straight into
+// a player or encoder without buffering it all in memory. It implements
+// io.WriterTo; use WriteToContext to bound it with a context.
+func (s *TTSStream) WriteTo(w io.Writer) (int64, error) {
+	return s.WriteToContext(context.Background(), w)
+}
+
+// WriteToContext is like WriteTo but stops with ctx.Err() when ctx is done.
+// It waits for the stream to be ready before reading audio, and returns the
+// stream's error if it ended abnormally.
+func (s *TTSStream) WriteToContext(ctx context.Context, w io.Writer) (int64, error) {
+	if err := s.WaitReady(ctx); err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for {
+		select {
+		case chunk, ok := <-s.audioCh:
+			if !ok {
+				return total, s.getError()
+			}
+			n, err := writeChunk(w, chunk)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
+
+		case <-ctx.Done():
+			return total, ctx.Err()
+		}
+	}
+}
+
+// Pipe writes each audio chunk to w as it arrives until the stream ends,
+// returning the stream's error if it ended abnormally. Unlike
+// WriteToContext, it finishes the stream itself when ctx is done: it sends
+// end-of-stream (unless SendEndOfStream was already called), keeps writing
+// the audio the server flushes for up to 2 seconds or until the stream
+// ends, then closes the stream and returns ctx.Err(). A write error closes
+// the stream and aborts the pipe immediately.
+//
+// Example:
+//
+//	cmd := exec.Command("ffmpeg", "-f", "s16le", "-ar", "48000", "-i", "-", "out.mp3")
+//	stdin, _ := cmd.StdinPipe()
+//	cmd.Start()
+//	go func() {
+//	    stream.SendText("Hello, world!")
+//	    stream.SendEndOfStream()
+//	}()
+//	err := stream.Pipe(ctx, stdin)
+//	stdin.Close()
+func (s *TTSStream) Pipe(ctx context.Context, w io.Writer) error {
+	if err := s.WaitReady(ctx); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case chunk, ok := <-s.audioCh:
+			if !ok {
+				return s.getError()
+			}
+			if _, err := writeChunk(w, chunk); err != nil {
+				_ = s.Close()
+				return err
+			}
+
+		case <-ctx.Done():
+			s.connMu.Lock()
+			if !s.endSent {
+				s.endSent = true
+				_ = s.sendLocked(wsMessage{Type: msgTypeEndOfStream})
+			}
+			s.connMu.Unlock()
+			err := s.drainTo(w, pipeDrainTimeout)
+			_ = s.Close()
+			if err != nil {
+				return err
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// pipeDrainTimeout bounds how long Pipe keeps writing audio after its
+// context is done. It is a variable so tests can shorten it.
+var pipeDrainTimeout = 2 * time.Second
+
+// drainTo writes the audio left on audioCh to w until the channel closes or
+// timeout elapses, returning the first write error.
+func (s *TTSStream) drainTo(w io.Writer, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case chunk, ok := <-s.audioCh:
+			if !ok {
+				return nil
+			}
+			if _, err := writeChunk(w, chunk); err != nil {
+				return err
+			}
+		case <-timer.C:
+			return nil
+		}
+	}
+}
+
+// Pipeline sends every string received on textCh with SendText while
+// forwarding audio to the returned channel, so audio starts playing before
+// all the text has been produced, for example while an LLM is still
+// generating its reply. End-of-stream is sent once textCh is closed, and the
+// returned channel is closed when the stream ends; use DoneErr to learn why.
+//
+// Pipeline consumes Audio, so it must not be combined with other readers of
+// Audio, and it may only be called once per stream. Cancelling ctx stops both
+// directions and closes the stream. A caller that stops reading the returned
+// channel must cancel ctx or call Close: otherwise the forwarding goroutine
+// blocks on its next send, holding the WebSocket connection open.
+// A failed SendText ends the stream with its error.
+//
+// Example:
+//
+//	textCh := make(chan string)
+//	go func() {
+//	    defer close(textCh)
+//	    for token := range llmTokens {
+//	        textCh <- token
+//	    }
+//	}()
+//	audio, err := stream.Pipeline(ctx, textCh)
+//	for chunk := range audio {
+//	    player.Write(chunk)
+//	}
+func (s *TTSStream) Pipeline(ctx context.Context, textCh <-chan string) (<-chan []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !s.pipelined.CompareAndSwap(false, true) {
+		return nil, &Error{Message: "Pipeline already called on this stream"}
+	}
+
+	out := make(chan []byte)
+	go s.pipelineSend(ctx, textCh)
+	go s.pipelineReceive(ctx, out)
+	return out, nil
+}
+
+// pipelineSend sends the text received on textCh once the stream is ready,
+// followed by end-of-stream when textCh is closed.
+func (s *TTSStream) pipelineSend(ctx context.Context, textCh <-chan string) {
+	if err := s.WaitReady(ctx); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case text, ok := <-textCh:
+			if !ok {
+				_ = s.SendEndOfStream()
+				return
+			}
+			if err := s.SendText(text); err != nil {
+				s.setError(err)
+				_ = s.Close()
+				return
+			}
+
+		case <-ctx.Done():
+			_ = s.Close()
+			return
+
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// pipelineReceive forwards audio to out until the stream ends or is closed,
+// or ctx is done, then closes out.
+func (s *TTSStream) pipelineReceive(ctx context.Context, out chan<- []byte) {
+	defer close(out)
+
+	for {
+		select {
+		case chunk, ok := <-s.audioCh:
+			if !ok {
+				return
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				_ = s.Close()
+				return
+			case <-s.closing:
+				return
+			}
+
+		case <-ctx.Done():
+			_ = s.Close()
+			return
+
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// writeChunk writes chunk to w, reporting a short write as
+// io.ErrShortWrite.
+func writeChunk(w io.Writer, chunk []byte) (int, error) {
+	n, err := w.Write(chunk)
+	if err == nil && n < len(chunk) {
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
+
+// FirstAudioAt returns the wall-clock time at which the first audio chunk was
+// received and true, or the zero time and false if no audio has arrived yet.
+// It is useful for synchronising playback with other events such as a UI
+// animation.
+func (s *TTSStream) FirstAudioAt() (time.Time, bool) {
+	nanos := s.firstAudioAt.Load()
+	if nanos == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+// TimeToReady returns how long the server took to report ready, measured
+// from the Stream call, or 0 if it has not reported ready yet.
+func (s *TTSStream) TimeToReady() time.Duration {
+	return sinceStart(s.startedAt, s.readyAt.Load())
+}
+
+// TimeToFirstAudio returns how long the first audio chunk took to arrive,
+// measured from the Stream call, or 0 if no audio has arrived yet. This is
+// the stream's time-to-first-audio (TTFA).
+func (s *TTSStream) TimeToFirstAudio() time.Duration {
+	return sinceStart(s.startedAt, s.firstAudioAt.Load())
+}
+
+// sinceStart returns the time from start to the Unix time nanos, or 0 if
+// nanos is 0.
+func sinceStart(start time.Time, nanos int64) time.Duration {
+	if nanos == 0 {
+		return 0
+	}
+	return time.Unix(0, nanos).Sub(start)
+}
+
+// SessionID returns the session ID assigned by the server in the WebSocket
+// upgrade response (x-request-id header), or an empty string if none was
+// sent. Use it to correlate a stream with server-side logs.
+func (s *TTSStream) SessionID() string {
+	return s.sessionID
+}
+
+// RequestID returns the request ID from the ready message. It is safe to
+// call at any time, but returns an empty string until the stream is ready;
+// after WaitReady returns nil it always holds the final value.
 func (s *TTSStream) RequestID() string {
+	s.requestIDMu.RLock()
+	defer s.requestIDMu.RUnlock()
 	return s.requestID
 }
 
-// Close closes the stream.
+// Reconnects returns a channel that receives a value each time the stream
+// reconnects (see WithAutoReconnect). Notifications are dropped if the
+// previous one has not been read. The channel is closed when the stream ends.
+func (s *TTSStream) Reconnects() <-chan struct{} {
+	return s.reconnectCh
+}
+
+// isClosed reports whether Close has been called.
+func (s *TTSStream) isClosed() bool {
+	select {
+	case <-s.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the stream. Audio still buffered on Audio is discarded in the
+// background; callers should not read it after Close.
 func (s *TTSStream) Close() error {
 	var err error
 	s.closeOnce.Do(func() {
-		err = s.conn.Close()
+		close(s.closing)
+		go s.drain()
+		err = s.conn.Load().Close()
 	})
 	return err
 }
 
+// drain discards any audio left on audioCh until it is closed, so a caller
+// that stopped reading early never holds up handleMessages after Close.
+func (s *TTSStream) drain() {
+	for range s.audioCh {
+	}
+}
+
 // Done returns a channel that's closed when the stream ends.
 func (s *TTSStream) Done() <-chan struct{} {
 	return s.done
 }
+
+// DoneErr returns a channel that receives exactly one value when the stream
+// ends and is then closed. The value is nil after a clean end of stream, or
+// the error that ended it otherwise.
+func (s *TTSStream) DoneErr() <-chan error {
+	return s.doneErr
+}
+
+// signalDoneErr delivers the final stream error on doneErr. It runs once,
+// when handleMessages returns.
+func (s *TTSStream) signalDoneErr() {
+	s.doneErr <- s.getError()
+	close(s.doneErr)
+}
@@ -0,0 +1,82 @@
+package gradium
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// BenchmarkTTSStreamThroughput measures how fast a TTS stream receives large
+// audio frames with the default WebSocket read buffer and with a 64 KiB one.
+func BenchmarkTTSStreamThroughput(b *testing.B) {
+	for _, bench := range []struct {
+		name    string
+		readBuf int
+	}{
+		{name: "default", readBuf: 0},
+		{name: "64KiB", readBuf: 65536},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			benchmarkTTSStreamThroughput(b, bench.readBuf)
+		})
+	}
+}
+
+func benchmarkTTSStreamThroughput(b *testing.B, readBuf int) {
+	pcm := make([]byte, 32*1024)
+	msg, _ := json.Marshal(ttsAudioMessage{Type: "audio", Audio: base64.StdEncoding.EncodeToString(pcm)})
+	frames := b.N
+
+	upgrader := websocket.Upgrader{WriteBufferSize: 65536}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(ttsReadyMessage{Type: "ready", RequestID: "req-123"})
+		for i := 0; i < frames; i++ {
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		}
+		conn.WriteJSON(wsMessage{Type: msgTypeEndOfStream})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(
+		WithAPIKey("test-key"),
+		WithWebSocketBufferSizes(readBuf, 0),
+		WithTTSAudioChannelSize(1024),
+	)
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	b.SetBytes(int64(len(pcm)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	received := 0
+	for chunk := range stream.Audio() {
+		received += len(chunk)
+	}
+	b.StopTimer()
+	if want := frames * len(pcm); received != want {
+		b.Logf("received %d of %d bytes; %d frames dropped", received, want, stream.Dropped())
+	}
+}
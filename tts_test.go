@@ -1,13 +1,19 @@
 package gradium
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -539,6 +545,168 @@ func TestTTSStream_WithJSONConfig(t *testing.T) {
 	mu.Unlock()
 }
 
+func TestTTSStream_InvalidJSONConfig(t *testing.T) {
+	dialed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dialed = true
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	_, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+		JSONConfig:   &TTSConfig{Speed: 1.5},
+	})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+	if dialed {
+		t.Error("expected no connection for an invalid config")
+	}
+}
+
+func TestTTSStream_WithLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		language   string
+		locale     string
+		wantFields map[string]interface{}
+	}{
+		{
+			name:       "language and locale",
+			language:   "fr",
+			locale:     "fr-CA",
+			wantFields: map[string]interface{}{"language": "fr", "locale": "fr-CA"},
+		},
+		{
+			name:       "language only",
+			language:   "de",
+			wantFields: map[string]interface{}{"language": "de"},
+		},
+		{
+			name:       "neither",
+			wantFields: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var setup map[string]interface{}
+			var mu sync.Mutex
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				_, msg, _ := conn.ReadMessage()
+				mu.Lock()
+				json.Unmarshal(msg, &setup)
+				mu.Unlock()
+
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.ReadMessage()
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{
+				VoiceID:      "voice-123",
+				OutputFormat: FormatPCM,
+				Language:     tt.language,
+				Locale:       tt.locale,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			// The server reads the setup message before sending ready
+			if err := stream.WaitReady(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, key := range []string{"language", "locale"} {
+				want, wantOK := tt.wantFields[key]
+				got, gotOK := setup[key]
+				if wantOK != gotOK || got != want {
+					t.Errorf("expected %s %v (present %v), got %v (present %v)", key, want, wantOK, got, gotOK)
+				}
+			}
+		})
+	}
+}
+
+func TestTTSStream_WithJSONConfigExtra(t *testing.T) {
+	var receivedConfig map[string]interface{}
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, msg, _ := conn.ReadMessage()
+		var setup map[string]interface{}
+		json.Unmarshal(msg, &setup)
+
+		mu.Lock()
+		if cfg, ok := setup["json_config"].(map[string]interface{}); ok {
+			receivedConfig = cfg
+		}
+		mu.Unlock()
+
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+		JSONConfig: &TTSConfig{
+			PaddingBonus: 1.5,
+			Extra: map[string]interface{}{
+				"new_feature":   true,
+				"padding_bonus": -3.0, // overridden by the typed field
+			},
+		},
+	})
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream.WaitReady(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if receivedConfig == nil {
+		t.Fatal("expected json_config to be sent")
+	}
+	if receivedConfig["new_feature"] != true {
+		t.Errorf("expected new_feature true, got %v", receivedConfig["new_feature"])
+	}
+	if receivedConfig["padding_bonus"] != 1.5 {
+		t.Errorf("expected typed padding_bonus 1.5 to win, got %v", receivedConfig["padding_bonus"])
+	}
+}
+
 func TestTTSStream_DefaultModelName(t *testing.T) {
 	var receivedModelName string
 	var mu sync.Mutex
@@ -581,3 +749,1862 @@ func TestTTSStream_DefaultModelName(t *testing.T) {
 	}
 	mu.Unlock()
 }
+
+func TestSentenceSplitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		maxLen int
+		want   []string
+	}{
+		{
+			name:   "short text unchanged",
+			text:   "Hello. World.",
+			maxLen: 100,
+			want:   []string{"Hello. World."},
+		},
+		{
+			name:   "no limit",
+			text:   "Hello. World.",
+			maxLen: 0,
+			want:   []string{"Hello. World."},
+		},
+		{
+			name:   "split at sentence boundaries",
+			text:   "First one. Second one! Third one?",
+			maxLen: 12,
+			want:   []string{"First one. ", "Second one! ", "Third one?"},
+		},
+		{
+			name:   "pack sentences into chunks",
+			text:   "One. Two. Three. Four.",
+			maxLen: 10,
+			want:   []string{"One. Two. ", "Three. ", "Four."},
+		},
+		{
+			name:   "decimal is not a boundary",
+			text:   "Pi is 3.14 today. Yes.",
+			maxLen: 18,
+			want:   []string{"Pi is 3.14 today. ", "Yes."},
+		},
+		{
+			name:   "long sentence split at whitespace",
+			text:   "aaaa bbbb cccc",
+			maxLen: 7,
+			want:   []string{"aaaa ", "bbbb ", "cccc"},
+		},
+		{
+			name:   "long word split at rune boundary",
+			text:   "ééééé",
+			maxLen: 3,
+			want:   []string{"é", "é", "é", "é", "é"},
+		},
+		{
+			name:   "abbreviations are not boundaries",
+			text:   "Dr. Smith met Mrs. Jones. Then they left.",
+			maxLen: 30,
+			want:   []string{"Dr. Smith met Mrs. Jones. ", "Then they left."},
+		},
+		{
+			name:   "initials are not boundaries",
+			text:   "J. R. R. Tolkien wrote. He did.",
+			maxLen: 25,
+			want:   []string{"J. R. R. Tolkien wrote. ", "He did."},
+		},
+		{
+			name:   "no ends a sentence",
+			text:   "He said no. Then he left.",
+			maxLen: 15,
+			want:   []string{"He said no. ", "Then he left."},
+		},
+		{
+			name:   "no before a number is not a boundary",
+			text:   "See No. 5 for it. Then go.",
+			maxLen: 20,
+			want:   []string{"See No. 5 for it. ", "Then go."},
+		},
+		{
+			name:   "co ends a sentence",
+			text:   "They run a co. Now it grows.",
+			maxLen: 16,
+			want:   []string{"They run a co. ", "Now it grows."},
+		},
+		{
+			name:   "co after a company name is not a boundary",
+			text:   "Smith & Co. Ltd and Acme Co. Group met. Done.",
+			maxLen: 40,
+			want:   []string{"Smith & Co. Ltd and Acme Co. Group met. ", "Done."},
+		},
+		{
+			name:   "ellipsis is not a boundary",
+			text:   "Wait... what happened? Nothing.",
+			maxLen: 25,
+			want:   []string{"Wait... what happened? ", "Nothing."},
+		},
+		{
+			name:   "no whitespace split after ellipsis",
+			text:   "Well… ok then",
+			maxLen: 10,
+			want:   []string{"Well… ok", " then"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SentenceSplitter{}.Split(tt.text, tt.maxLen)
+			if strings.Join(got, "") != tt.text {
+				t.Errorf("chunks %q do not reassemble to %q", got, tt.text)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("chunk %d: expected %q, got %q", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+type fixedSplitter struct {
+	chunks []string
+}
+
+func (f fixedSplitter) Split(_ string, _ int) []string {
+	return f.chunks
+}
+
+func TestTTSService_CreateSplitsText(t *testing.T) {
+	tests := []struct {
+		name     string
+		splitter TTSTextSplitter
+		maxLen   int
+		maxChunk int
+		text     string
+		want     []string
+	}{
+		{
+			name:     "sentence splitter",
+			splitter: SentenceSplitter{},
+			maxLen:   20,
+			text:     "Hello there. How are you today?",
+			want:     []string{"Hello there. ", "How are you today?"},
+		},
+		{
+			name:     "custom splitter",
+			splitter: fixedSplitter{chunks: []string{"a", "b", "c"}},
+			text:     "ignored",
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "per-call MaxChunkBytes",
+			splitter: SentenceSplitter{},
+			maxLen:   100,
+			maxChunk: 20,
+			text:     "Hello there. How are you today?",
+			want:     []string{"Hello there. ", "How are you today?"},
+		},
+		{
+			name:     "zero limits use the 4096-byte default",
+			splitter: SentenceSplitter{},
+			text:     strings.Repeat("Hello there. ", 400),
+			want: []string{
+				strings.Repeat("Hello there. ", 315),
+				strings.Repeat("Hello there. ", 85),
+			},
+		},
+		{
+			name:     "negative MaxChunkBytes disables splitting",
+			splitter: SentenceSplitter{},
+			maxChunk: -1,
+			text:     strings.Repeat("Hello there. ", 400),
+			want:     []string{strings.Repeat("Hello there. ", 400)},
+		},
+		{
+			name:     "negative client limit disables splitting",
+			splitter: SentenceSplitter{},
+			maxLen:   -1,
+			text:     strings.Repeat("Hello there. ", 400),
+			want:     []string{strings.Repeat("Hello there. ", 400)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []string
+			var mu sync.Mutex
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+				// Read text messages until EOS
+				for {
+					var textMsg ttsTextMessage
+					if err := conn.ReadJSON(&textMsg); err != nil {
+						return
+					}
+					if textMsg.Type == "end_of_stream" {
+						break
+					}
+					mu.Lock()
+					received = append(received, textMsg.Text)
+					mu.Unlock()
+				}
+
+				conn.WriteJSON(map[string]string{
+					"type":  "audio",
+					"audio": base64.StdEncoding.EncodeToString([]byte("audio")),
+				})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(
+				WithAPIKey("test-key"),
+				WithBaseURL(server.URL),
+				WithTTSTextSplitter(tt.splitter, tt.maxLen),
+			)
+			client.wsURL = wsURL
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if _, err := client.TTS.Create(ctx, TTSParams{
+				VoiceID:       "voice-123",
+				OutputFormat:  FormatPCM,
+				Text:          tt.text,
+				MaxChunkBytes: tt.maxChunk,
+			}); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(received) != len(tt.want) {
+				t.Fatalf("expected %q, got %q", tt.want, received)
+			}
+			for i := range received {
+				if received[i] != tt.want[i] {
+					t.Errorf("message %d: expected %q, got %q", i, tt.want[i], received[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTTSStream_WaitReadyIdempotent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := stream.WaitReady(ctx); err != nil {
+		t.Fatalf("first WaitReady failed: %v", err)
+	}
+
+	start := time.Now()
+	if err := stream.WaitReady(ctx); err != nil {
+		t.Fatalf("second WaitReady failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected second WaitReady to return immediately, took %v", elapsed)
+	}
+	if stream.RequestID() != "req-123" {
+		t.Errorf("expected request ID 'req-123', got %q", stream.RequestID())
+	}
+}
+
+func TestTTSService_CreateSampleRate(t *testing.T) {
+	tests := []struct {
+		format   OutputFormat
+		expected int
+	}{
+		{FormatPCM16000, 16000},
+		{FormatPCM24000, 24000},
+		{FormatULaw8000, 8000},
+		{FormatALaw8000, 8000},
+		{FormatPCM, 48000},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				if setup.OutputFormat != tt.format {
+					t.Errorf("expected format %q, got %q", tt.format, setup.OutputFormat)
+				}
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+				var msg wsMessage
+				conn.ReadJSON(&msg)
+				conn.ReadJSON(&msg)
+
+				conn.WriteJSON(map[string]string{
+					"type":  "audio",
+					"audio": base64.StdEncoding.EncodeToString([]byte("audio")),
+				})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := client.TTS.Create(ctx, TTSParams{
+				VoiceID:      "voice-123",
+				OutputFormat: tt.format,
+				Text:         "Hello",
+			})
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			if result.SampleRate != tt.expected {
+				t.Errorf("expected sample rate %d, got %d", tt.expected, result.SampleRate)
+			}
+			if result.Channels != 1 || result.Format != tt.format {
+				t.Errorf("expected mono %q, got %d channels of %q", tt.format, result.Channels, result.Format)
+			}
+		})
+	}
+}
+
+func TestTTSStream_FirstAudioAt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		// Wait for text before sending audio
+		var textMsg ttsTextMessage
+		conn.ReadJSON(&textMsg)
+
+		for i := 0; i < 2; i++ {
+			conn.WriteJSON(map[string]string{
+				"type":  "audio",
+				"audio": base64.StdEncoding.EncodeToString([]byte("chunk")),
+			})
+			time.Sleep(20 * time.Millisecond)
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, _ := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream.WaitReady(ctx)
+
+	if _, ok := stream.FirstAudioAt(); ok {
+		t.Error("expected FirstAudioAt to be unset before any audio")
+	}
+
+	before := time.Now()
+	stream.SendText("Hello")
+
+	var firstChunkAt time.Time
+	for range stream.Audio() {
+		if firstChunkAt.IsZero() {
+			firstChunkAt = time.Now()
+		}
+	}
+
+	first, ok := stream.FirstAudioAt()
+	if !ok {
+		t.Fatal("expected FirstAudioAt to be set")
+	}
+	if first.Before(before) || first.After(firstChunkAt) {
+		t.Errorf("expected FirstAudioAt between %v and %v, got %v", before, firstChunkAt, first)
+	}
+}
+
+func TestTTSStream_AudioChannelSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTTSAudioChannelSize(4))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	defer stream.Close()
+
+	if c := cap(stream.Audio()); c != 4 {
+		t.Errorf("expected audio channel capacity 4, got %d", c)
+	}
+}
+
+func TestTTSStream_SessionID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, http.Header{"X-Request-Id": []string{"session-abc"}})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	defer stream.Close()
+
+	if stream.SessionID() != "session-abc" {
+		t.Errorf("expected session ID 'session-abc', got %q", stream.SessionID())
+	}
+}
+
+func TestTTSStream_HandshakeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"detail": "Access denied"}`))
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	_, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+
+	connErr, ok := err.(*ConnectionError)
+	if !ok {
+		t.Fatalf("expected ConnectionError, got %T", err)
+	}
+	if !strings.Contains(connErr.Message, "TTS WebSocket") || !strings.Contains(connErr.Message, "status 403") {
+		t.Errorf("unexpected error message: %q", connErr.Message)
+	}
+}
+
+func TestTTSStream_DoneErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		final   map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:  "clean end of stream",
+			final: map[string]interface{}{"type": "end_of_stream"},
+		},
+		{
+			name:    "server error",
+			final:   map[string]interface{}{"type": "error", "message": "boom", "code": 500},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(tt.final)
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			stream, _ := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			defer stream.Close()
+
+			select {
+			case err := <-stream.DoneErr():
+				var wsErr *WebSocketError
+				if tt.wantErr && !errors.As(err, &wsErr) {
+					t.Errorf("expected WebSocketError, got %v", err)
+				}
+				if !tt.wantErr && err != nil {
+					t.Errorf("expected nil error, got %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("DoneErr did not deliver within timeout")
+			}
+
+			if _, ok := <-stream.DoneErr(); ok {
+				t.Error("expected DoneErr channel to be closed after one value")
+			}
+			select {
+			case <-stream.Done():
+			default:
+				t.Error("expected Done to be closed")
+			}
+		})
+	}
+}
+
+func TestTTSService_CreateEmptyAudio(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantType string
+	}{
+		{name: "empty text", text: "", wantType: "*gradium.ValidationError"},
+		{name: "no audio for text", text: "Hello", wantType: "*gradium.EmptyResponseError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+				// Read until end of stream, then end without audio
+				for {
+					var msg wsMessage
+					if err := conn.ReadJSON(&msg); err != nil || msg.Type == "end_of_stream" {
+						break
+					}
+				}
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := client.TTS.Create(ctx, TTSParams{
+				VoiceID:      "voice-123",
+				OutputFormat: FormatPCM,
+				Text:         tt.text,
+			})
+
+			var gotType string
+			var validationErr *ValidationError
+			var emptyErr *EmptyResponseError
+			switch {
+			case errors.As(err, &validationErr):
+				gotType = "*gradium.ValidationError"
+			case errors.As(err, &emptyErr):
+				gotType = "*gradium.EmptyResponseError"
+			}
+			if gotType != tt.wantType {
+				t.Errorf("expected %s, got %v", tt.wantType, err)
+			}
+		})
+	}
+}
+
+func TestTTSStream_NoDrainLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		// More chunks than the client will read
+		chunk := base64.StdEncoding.EncodeToString([]byte("audio"))
+		for i := 0; i < 10; i++ {
+			conn.WriteJSON(map[string]string{"type": "audio", "audio": chunk})
+		}
+
+		var msg wsMessage
+		conn.ReadJSON(&msg)
+	}))
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTTSAudioChannelSize(2))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-stream.Audio():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no audio received")
+	}
+
+	stream.Close()
+	<-stream.Done()
+	server.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines leaked: %d before, %d after Close", before, runtime.NumGoroutine())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTTSStream_Unknown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]interface{}{"type": "word_alignment", "word": "Hello", "start_s": 0.1})
+		conn.WriteJSON(map[string]string{
+			"type":  "audio",
+			"audio": base64.StdEncoding.EncodeToString([]byte("audio")),
+		})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	result, err := stream.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(result.RawData) != "audio" {
+		t.Errorf("expected audio 'audio', got %q", result.RawData)
+	}
+
+	var events []TTSUnknownEvent
+	for ev := range stream.Unknown() {
+		events = append(events, ev)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 unknown event, got %d", len(events))
+	}
+	if events[0].Type != "word_alignment" {
+		t.Errorf("expected type 'word_alignment', got %q", events[0].Type)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(events[0].Raw, &raw); err != nil {
+		t.Fatalf("failed to decode raw message: %v", err)
+	}
+	if raw["word"] != "Hello" {
+		t.Errorf("expected raw word 'Hello', got %v", raw["word"])
+	}
+}
+
+func TestTTSStream_UnknownBuffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []TTSStreamOption
+		wantTypes []string
+	}{
+		{name: "default buffer", wantTypes: []string{"metrics", "word_alignment", "metrics"}},
+		{name: "small buffer drops overflow", opts: []TTSStreamOption{WithTTSUnknownBuffer(1)}, wantTypes: []string{"metrics"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]interface{}{"type": "metrics", "rtf": 0.5})
+				conn.WriteJSON(map[string]interface{}{"type": "word_alignment", "word": "Hello"})
+				conn.WriteJSON(map[string]interface{}{"type": "metrics", "rtf": 0.4})
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM}, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+			<-stream.Done()
+
+			var types []string
+			for ev := range stream.Unknown() {
+				types = append(types, ev.Type)
+				var raw map[string]interface{}
+				if err := json.Unmarshal(ev.Raw, &raw); err != nil || raw["type"] != ev.Type {
+					t.Errorf("expected raw JSON of a %s message, got %s", ev.Type, ev.Raw)
+				}
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("expected %v, got %v", tt.wantTypes, types)
+			}
+		})
+	}
+}
+
+func TestTTSStream_RequestIDConcurrentAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		time.Sleep(20 * time.Millisecond)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	// Read RequestID without waiting for ready; run with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stream.Done():
+					return
+				default:
+					if id := stream.RequestID(); id != "" && id != "req-123" {
+						t.Errorf("unexpected request ID %q", id)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if id := stream.RequestID(); id != "req-123" {
+		t.Errorf("expected request ID 'req-123', got %q", id)
+	}
+}
+
+func TestTTSStream_DialContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		// Stall the handshake until the client gives up
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelExpired()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "cancelled", ctx: cancelled, wantErr: context.Canceled},
+		{name: "deadline exceeded", ctx: expired, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.TTS.Stream(tt.ctx, TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v (%T)", tt.wantErr, err, err)
+			}
+		})
+	}
+}
+
+func TestTTSStream_AudioBeforeRangeStart(t *testing.T) {
+	chunks := []string{"one", "two", "three", "four", "five"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		// Send audio straight after ready, before any text arrives
+		for _, chunk := range chunks {
+			conn.WriteJSON(map[string]string{
+				"type":  "audio",
+				"audio": base64.StdEncoding.EncodeToString([]byte(chunk)),
+			})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{
+		VoiceID:      "voice-123",
+		OutputFormat: FormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if err := stream.WaitReady(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stream.SendText("hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Start ranging only after the whole stream has been received
+	<-stream.Done()
+
+	var received []string
+	for chunk := range stream.Audio() {
+		received = append(received, string(chunk))
+	}
+	if !reflect.DeepEqual(received, chunks) {
+		t.Errorf("expected chunks %v, got %v", chunks, received)
+	}
+}
+
+func TestTTSStream_WriteTo(t *testing.T) {
+	chunks := [][]byte{[]byte("chunk1"), []byte("chunk2"), []byte("chunk3")}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		var msg wsMessage
+		conn.ReadJSON(&msg)
+		conn.ReadJSON(&msg)
+
+		for _, chunk := range chunks {
+			conn.WriteJSON(map[string]string{
+				"type":  "audio",
+				"audio": base64.StdEncoding.EncodeToString(chunk),
+			})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	stream.SendText("Hello")
+	stream.SendEndOfStream()
+
+	var writerTo io.WriterTo = stream
+	var buf bytes.Buffer
+	n, err := writerTo.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+
+	expected := "chunk1chunk2chunk3"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if n != int64(len(expected)) {
+		t.Errorf("expected %d bytes written, got %d", len(expected), n)
+	}
+}
+
+func TestTTSStream_WriteToContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  func(conn *websocket.Conn)
+		writer  io.Writer
+		timeout time.Duration
+		wantN   int64
+		wantErr func(error) bool
+	}{
+		{
+			name: "stream error",
+			server: func(conn *websocket.Conn) {
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("abc"))})
+				conn.WriteJSON(map[string]interface{}{"type": "error", "message": "boom", "code": 500})
+			},
+			writer:  io.Discard,
+			timeout: 5 * time.Second,
+			wantN:   -1, // 0 or 3, depending on whether the error lands before WaitReady
+			wantErr: func(err error) bool { return errors.Is(err, ErrWebSocket) },
+		},
+		{
+			name: "writer error",
+			server: func(conn *websocket.Conn) {
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("abc"))})
+				conn.ReadMessage()
+			},
+			writer:  failingWriter{},
+			timeout: 5 * time.Second,
+			wantErr: func(err error) bool { return errors.Is(err, errWriteFailed) },
+		},
+		{
+			name: "context done before ready",
+			server: func(conn *websocket.Conn) {
+				conn.ReadMessage()
+			},
+			writer:  io.Discard,
+			timeout: 50 * time.Millisecond,
+			wantErr: func(err error) bool { return errors.Is(err, context.DeadlineExceeded) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				tt.server(conn)
+			}))
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = wsURL
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			n, err := stream.WriteToContext(ctx, tt.writer)
+			if !tt.wantErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantN >= 0 && n != tt.wantN {
+				t.Errorf("expected %d bytes written, got %d", tt.wantN, n)
+			}
+		})
+	}
+}
+
+func TestTTSStream_Pipe(t *testing.T) {
+	audioMsg := func(data string) map[string]string {
+		return map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte(data))}
+	}
+
+	tests := []struct {
+		name      string
+		sendEnd   bool
+		writer    func(b *bytes.Buffer, cancel context.CancelFunc) io.Writer
+		wantAudio string
+		wantErr   func(error) bool
+	}{
+		{
+			name:      "stream completes",
+			sendEnd:   true,
+			writer:    func(b *bytes.Buffer, _ context.CancelFunc) io.Writer { return b },
+			wantAudio: "abcdef",
+			wantErr:   func(err error) bool { return err == nil },
+		},
+		{
+			name:      "context cancelled drains the stream",
+			writer:    func(b *bytes.Buffer, cancel context.CancelFunc) io.Writer { return cancelOnWrite{b, cancel} },
+			wantAudio: "abcdef",
+			wantErr:   func(err error) bool { return errors.Is(err, context.Canceled) },
+		},
+		{
+			name:    "writer error",
+			sendEnd: true,
+			writer:  func(*bytes.Buffer, context.CancelFunc) io.Writer { return failingWriter{} },
+			wantErr: func(err error) bool { return errors.Is(err, errWriteFailed) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(audioMsg("abc"))
+
+				// Flush the rest of the audio only once the client ends the input.
+				for {
+					var msg wsMessage
+					if err := conn.ReadJSON(&msg); err != nil {
+						return
+					}
+					if msg.Type == "end_of_stream" {
+						break
+					}
+				}
+				conn.WriteJSON(audioMsg("def"))
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.sendEnd {
+				stream.SendEndOfStream()
+			}
+
+			var buf bytes.Buffer
+			err = stream.Pipe(ctx, tt.writer(&buf, cancel))
+			if !tt.wantErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tt.wantAudio {
+				t.Errorf("expected audio %q, got %q", tt.wantAudio, got)
+			}
+		})
+	}
+}
+
+func TestTTSStream_PipeClosesStream(t *testing.T) {
+	defer func(d time.Duration) { pipeDrainTimeout = d }(pipeDrainTimeout)
+	pipeDrainTimeout = 50 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		writer  func(b *bytes.Buffer, cancel context.CancelFunc) io.Writer
+		wantErr error
+	}{
+		{
+			name:    "server never ends the stream after cancellation",
+			writer:  func(b *bytes.Buffer, cancel context.CancelFunc) io.Writer { return cancelOnWrite{b, cancel} },
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "writer error",
+			writer:  func(*bytes.Buffer, context.CancelFunc) io.Writer { return failingWriter{} },
+			wantErr: errWriteFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+				conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("abc"))})
+
+				// Never end the stream; wait for the client to close it.
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				var buf bytes.Buffer
+				errCh <- stream.Pipe(ctx, tt.writer(&buf, cancel))
+			}()
+
+			select {
+			case err := <-errCh:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Pipe did not return")
+			}
+			select {
+			case <-stream.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected Pipe to close the stream")
+			}
+		})
+	}
+}
+
+// cancelOnWrite cancels a context once the first chunk is written.
+type cancelOnWrite struct {
+	w      io.Writer
+	cancel context.CancelFunc
+}
+
+func (c cancelOnWrite) Write(p []byte) (int, error) {
+	c.cancel()
+	return c.w.Write(p)
+}
+
+var errWriteFailed = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWriteFailed }
+
+func TestTTSStream_AutoReconnect(t *testing.T) {
+	var mu sync.Mutex
+	var connections int
+	var replayed []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		mu.Lock()
+		connections++
+		first := connections == 1
+		mu.Unlock()
+
+		var setup ttsSetupMessage
+		if err := conn.ReadJSON(&setup); err != nil || setup.VoiceID != "voice-123" {
+			t.Errorf("expected setup message on every connection, got %+v (%v)", setup, err)
+			return
+		}
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		if first {
+			var msg ttsTextMessage
+			conn.ReadJSON(&msg)
+			conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("one")), "client_req_id": msg.ClientReqID})
+			// Drop the connection after the second text, before its audio
+			conn.ReadJSON(&msg)
+			return
+		}
+
+		for {
+			var msg ttsTextMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			mu.Lock()
+			replayed = append(replayed, msg.Type+":"+msg.Text)
+			mu.Unlock()
+			if msg.Type == "end_of_stream" {
+				break
+			}
+		}
+		conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("two"))})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.TTS.Stream(ctx, TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM}, WithAutoReconnect(3, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	stream.SendText("one ")
+	if chunk := <-stream.Audio(); string(chunk) != "one" {
+		t.Fatalf("expected first chunk %q, got %q", "one", chunk)
+	}
+	stream.SendText("two")
+	stream.SendEndOfStream()
+
+	var rest []byte
+	for chunk := range stream.Audio() {
+		rest = append(rest, chunk...)
+	}
+	if string(rest) != "two" {
+		t.Errorf("expected audio %q after reconnect, got %q", "two", rest)
+	}
+	if err := <-stream.DoneErr(); err != nil {
+		t.Errorf("unexpected stream error: %v", err)
+	}
+
+	select {
+	case _, ok := <-stream.Reconnects():
+		if !ok {
+			t.Error("expected a reconnect notification")
+		}
+	default:
+		t.Error("expected a reconnect notification")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if connections != 2 {
+		t.Errorf("expected 2 connections, got %d", connections)
+	}
+	// Only text sent after the last audio chunk is replayed
+	if want := []string{"text:two", "end_of_stream:"}; !reflect.DeepEqual(replayed, want) {
+		t.Errorf("expected replay %v, got %v", want, replayed)
+	}
+}
+
+func TestTTSStream_AutoReconnectUnconfirmedText(t *testing.T) {
+	audioFor := func(msg ttsTextMessage) map[string]string {
+		return map[string]string{
+			"type":          "audio",
+			"audio":         base64.StdEncoding.EncodeToString([]byte(strings.TrimSpace(msg.Text))),
+			"client_req_id": msg.ClientReqID,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		echoID     bool
+		wantReplay []string
+		wantAudio  string
+	}{
+		{
+			name:       "only text without audio is replayed",
+			echoID:     true,
+			wantReplay: []string{"text:two", "end_of_stream:"},
+			wantAudio:  "onetwo",
+		},
+		{
+			name:       "audio without an ID confirms nothing",
+			wantReplay: []string{"text:one ", "text:two", "end_of_stream:"},
+			wantAudio:  "oneonetwo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var connections int
+			var replayed []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := wsUpgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				mu.Lock()
+				connections++
+				first := connections == 1
+				mu.Unlock()
+
+				var setup ttsSetupMessage
+				conn.ReadJSON(&setup)
+				conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+				if first {
+					// Both texts arrive, then the connection drops after the
+					// audio for the first and before the audio for the second.
+					var one, two ttsTextMessage
+					conn.ReadJSON(&one)
+					conn.ReadJSON(&two)
+					if !tt.echoID {
+						one.ClientReqID = ""
+					}
+					conn.WriteJSON(audioFor(one))
+					return
+				}
+
+				var texts []ttsTextMessage
+				for {
+					var msg ttsTextMessage
+					if err := conn.ReadJSON(&msg); err != nil {
+						return
+					}
+					mu.Lock()
+					replayed = append(replayed, msg.Type+":"+msg.Text)
+					mu.Unlock()
+					if msg.Type == "end_of_stream" {
+						break
+					}
+					texts = append(texts, msg)
+				}
+				for _, msg := range texts {
+					conn.WriteJSON(audioFor(msg))
+				}
+				conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			stream, err := client.TTS.Stream(ctx, TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM}, WithAutoReconnect(3, 10*time.Millisecond))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer stream.Close()
+
+			stream.SendText("one ")
+			stream.SendText("two")
+			if chunk := <-stream.Audio(); string(chunk) != "one" {
+				t.Fatalf("expected first chunk %q, got %q", "one", chunk)
+			}
+			stream.SendEndOfStream()
+
+			audio := []byte("one")
+			for chunk := range stream.Audio() {
+				audio = append(audio, chunk...)
+			}
+			if string(audio) != tt.wantAudio {
+				t.Errorf("expected audio %q, got %q", tt.wantAudio, audio)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(replayed, tt.wantReplay) {
+				t.Errorf("expected replay %v, got %v", tt.wantReplay, replayed)
+			}
+		})
+	}
+}
+
+func TestTTSStream_AutoReconnectExhausted(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.Close()
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM}, WithAutoReconnect(2, time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	select {
+	case err := <-stream.DoneErr():
+		if !errors.Is(err, ErrWebSocket) {
+			t.Errorf("expected WebSocketError, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for stream to end")
+	}
+
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("expected 1 dial and 2 reconnect attempts, got %d", got)
+	}
+	if _, ok := <-stream.Reconnects(); ok {
+		t.Error("expected no reconnect notification")
+	}
+}
+
+func TestTTSStream_AutoReconnectClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM}, WithAutoReconnect(5, time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream.WaitReady(context.Background())
+	stream.Close()
+
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not end the stream")
+	}
+}
+
+func TestTTSStream_AutoReconnectCloseDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		conn.Close()
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM}, WithAutoReconnect(5, time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream.WaitReady(context.Background())
+	// Give the stream time to notice the drop and start backing off
+	time.Sleep(50 * time.Millisecond)
+	stream.Close()
+
+	select {
+	case <-stream.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not interrupt the reconnect backoff")
+	}
+}
+
+func TestTTSStream_Dropped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		for i := 0; i < 5; i++ {
+			conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte{byte(i)})})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithChannelBufferSize(2))
+	client.wsURL = wsURL
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	defer stream.Close()
+
+	// Let every chunk arrive before reading any
+	<-stream.Done()
+
+	received := 0
+	for range stream.Audio() {
+		received++
+	}
+	if received != 2 {
+		t.Errorf("expected 2 buffered chunks, got %d", received)
+	}
+	if got := stream.Dropped(); got != 3 {
+		t.Errorf("expected 3 dropped chunks, got %d", got)
+	}
+}
+
+func TestTTSStream_Latency(t *testing.T) {
+	const delay = 50 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		time.Sleep(delay)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+		time.Sleep(delay)
+		conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte("abc"))})
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if got := stream.TimeToReady(); got != 0 {
+		t.Errorf("expected TimeToReady 0 before ready, got %v", got)
+	}
+	if got := stream.TimeToFirstAudio(); got != 0 {
+		t.Errorf("expected TimeToFirstAudio 0 before audio, got %v", got)
+	}
+
+	if err := stream.WaitReady(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ready := stream.TimeToReady()
+	if ready < delay {
+		t.Errorf("expected TimeToReady of at least %v, got %v", delay, ready)
+	}
+
+	<-stream.Audio()
+	if got := stream.TimeToFirstAudio(); got < ready+delay {
+		t.Errorf("expected TimeToFirstAudio of at least %v, got %v", ready+delay, got)
+	}
+}
+
+func TestTTSStream_Pipeline(t *testing.T) {
+	// The server answers each text message with its own audio chunk right
+	// away, so audio can only arrive before textCh closes if Pipeline sends
+	// and receives concurrently.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var setup ttsSetupMessage
+		conn.ReadJSON(&setup)
+		conn.WriteJSON(map[string]string{"type": "ready", "request_id": "req-123"})
+
+		for {
+			var msg ttsTextMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "end_of_stream" {
+				break
+			}
+			conn.WriteJSON(map[string]string{"type": "audio", "audio": base64.StdEncoding.EncodeToString([]byte(msg.Text))})
+		}
+		conn.WriteJSON(map[string]string{"type": "end_of_stream"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	client.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	newStream := func(t *testing.T) *TTSStream {
+		t.Helper()
+		stream, err := client.TTS.Stream(context.Background(), TTSParams{VoiceID: "voice-123", OutputFormat: FormatPCM})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(func() { stream.Close() })
+		return stream
+	}
+
+	receive := func(t *testing.T, audio <-chan []byte) ([]byte, bool) {
+		t.Helper()
+		select {
+		case chunk, ok := <-audio:
+			return chunk, ok
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for audio")
+			return nil, false
+		}
+	}
+
+	t.Run("audio arrives while text is still being sent", func(t *testing.T) {
+		stream := newStream(t)
+		textCh := make(chan string)
+		audio, err := stream.Pipeline(context.Background(), textCh)
+		if err != nil {
+			t.Fatalf("Pipeline failed: %v", err)
+		}
+
+		for _, text := range []string{"Hello", " world"} {
+			textCh <- text
+			if chunk, ok := receive(t, audio); !ok || string(chunk) != text {
+				t.Fatalf("expected audio %q, got %q (open %v)", text, chunk, ok)
+			}
+		}
+		close(textCh)
+
+		if chunk, ok := receive(t, audio); ok {
+			t.Fatalf("expected audio channel to close, got %q", chunk)
+		}
+		if err := <-stream.DoneErr(); err != nil {
+			t.Errorf("expected clean end, got %v", err)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		stream := newStream(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		textCh := make(chan string)
+		audio, err := stream.Pipeline(ctx, textCh)
+		if err != nil {
+			t.Fatalf("Pipeline failed: %v", err)
+		}
+
+		// Abandon the audio channel without reading it.
+		textCh <- "unread"
+		cancel()
+
+		select {
+		case <-stream.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream was not closed after cancellation")
+		}
+		for {
+			if _, ok := receive(t, audio); !ok {
+				break
+			}
+		}
+	})
+
+	t.Run("closed without cancelling", func(t *testing.T) {
+		stream := newStream(t)
+		textCh := make(chan string)
+		audio, err := stream.Pipeline(context.Background(), textCh)
+		if err != nil {
+			t.Fatalf("Pipeline failed: %v", err)
+		}
+
+		// Abandon the audio channel with a chunk pending, then Close.
+		textCh <- "unread"
+		time.Sleep(50 * time.Millisecond)
+		stream.Close()
+
+		// The forwarding goroutine exits and closes the channel; at most the
+		// chunk it was holding is delivered first.
+		for i := 0; ; i++ {
+			if _, ok := receive(t, audio); !ok {
+				break
+			}
+			if i > 0 {
+				t.Fatal("expected the audio channel to close after Close")
+			}
+		}
+	})
+
+	t.Run("called twice", func(t *testing.T) {
+		stream := newStream(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if _, err := stream.Pipeline(ctx, make(chan string)); err != nil {
+			t.Fatalf("Pipeline failed: %v", err)
+		}
+		if _, err := stream.Pipeline(ctx, make(chan string)); err == nil {
+			t.Error("expected an error from the second Pipeline call")
+		}
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		stream := newStream(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := stream.Pipeline(ctx, make(chan string)); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -1,5 +1,21 @@
 package gradium
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultModelName is the model name sent in the setup message when
+// TTSParams.ModelName or STTParams.ModelName is empty and the client has no
+// default of its own (see WithDefaultTTSModel and WithDefaultSTTModel).
+const DefaultModelName = "default"
+
+// KnownSTTModels lists the STT model names documented at the time of this
+// release. The server may accept models not listed here.
+var KnownSTTModels = []string{DefaultModelName, "whisper"}
+
 // OutputFormat represents audio output formats for TTS.
 type OutputFormat string
 
@@ -14,6 +30,35 @@ const (
 	FormatPCM24000 OutputFormat = "pcm_24000"
 )
 
+// SampleRate returns the sample rate in Hz of audio produced in this format.
+// Formats without an explicit rate (wav, pcm, opus) use the native 48kHz, as
+// does any format unknown to OutputFormatSampleRate.
+func (f OutputFormat) SampleRate() int {
+	rate, err := OutputFormatSampleRate(f)
+	if err != nil {
+		return 48000
+	}
+	return rate
+}
+
+// OutputFormatSampleRate returns the sample rate in Hz of audio produced in
+// format f, or a ValidationError if f is not one of the Format constants.
+func OutputFormatSampleRate(f OutputFormat) (int, error) {
+	switch f {
+	case FormatWAV, FormatPCM, FormatOpus:
+		return 48000, nil
+	case FormatULaw8000, FormatALaw8000:
+		return 8000, nil
+	case FormatPCM16000:
+		return 16000, nil
+	case FormatPCM24000:
+		return 24000, nil
+	default:
+		msg := fmt.Sprintf("unknown output format %q", f)
+		return 0, &ValidationError{Errors: []ValidationErrorDetail{{Msg: msg, Loc: []interface{}{"output_format"}}}}
+	}
+}
+
 // InputFormat represents audio input formats for STT.
 type InputFormat string
 
@@ -26,13 +71,16 @@ const (
 
 // Voice represents a voice in the Gradium system.
 type Voice struct {
-	UID         string   `json:"uid"`
-	Name        string   `json:"name"`
-	Description *string  `json:"description,omitempty"`
-	Language    *string  `json:"language,omitempty"`
-	StartS      float64  `json:"start_s"`
-	StopS       *float64 `json:"stop_s,omitempty"`
-	Filename    string   `json:"filename"`
+	UID         string                   `json:"uid"`
+	Name        string                   `json:"name"`
+	Description *string                  `json:"description,omitempty"`
+	Language    *string                  `json:"language,omitempty"`
+	StartS      float64                  `json:"start_s"`
+	StopS       *float64                 `json:"stop_s,omitempty"`
+	Filename    string                   `json:"filename"`
+	Tags        []map[string]interface{} `json:"tags,omitempty"`
+	Rank        *float64                 `json:"rank,omitempty"`
+	UpdatedAt   *time.Time               `json:"updated_at,omitempty"`
 }
 
 // VoiceCreateParams contains parameters for creating a voice.
@@ -50,6 +98,10 @@ type VoiceCreateResponse struct {
 	UID        *string `json:"uid,omitempty"`
 	Error      *string `json:"error,omitempty"`
 	WasUpdated bool    `json:"was_updated"`
+
+	// Voice holds the created voice's metadata when the server includes it,
+	// saving a VoicesService.Get call. It is nil otherwise.
+	Voice *Voice `json:"voice,omitempty"`
 }
 
 // VoiceUpdateParams contains parameters for updating a voice.
@@ -62,11 +114,99 @@ type VoiceUpdateParams struct {
 	Rank        *float64                 `json:"rank,omitempty"`
 }
 
+// VoiceBatchUpdate is one update applied by VoicesService.UpdateBatch.
+type VoiceBatchUpdate struct {
+	UID string
+	VoiceUpdateParams
+}
+
+// VoiceListResponse is one page of voices returned by VoicesService.ListPage.
+type VoiceListResponse struct {
+	Voices []Voice
+	// Total is the number of voices across all pages, or -1 if the server
+	// did not report it.
+	Total int
+	// HasMore reports whether voices exist beyond this page.
+	HasMore bool
+	// NextCursor fetches the next page when set as VoiceListParams.Cursor.
+	// It is nil on the last page and when the server paginates by offset.
+	NextCursor *string
+}
+
+// voiceListPage is the paginated voice list response body. Servers that
+// predate cursors send a bare array instead.
+type voiceListPage struct {
+	Items      []Voice `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
 // VoiceListParams contains parameters for listing voices.
 type VoiceListParams struct {
 	Skip           int
 	Limit          int
 	IncludeCatalog bool
+	Language       *string
+	// Name filters voices by name; matching is done by the server.
+	Name *string
+	// Cursor resumes listing after a previous page; see
+	// VoiceListResponse.NextCursor and VoicesService.Iter.
+	Cursor *string
+}
+
+// VoiceListParamsBuilder builds VoiceListParams with method chaining.
+//
+// Example:
+//
+//	params := gradium.NewVoiceListParams().Limit(20).IncludeCatalog().Build()
+type VoiceListParamsBuilder struct {
+	params VoiceListParams
+}
+
+// NewVoiceListParams returns an empty VoiceListParamsBuilder.
+func NewVoiceListParams() *VoiceListParamsBuilder {
+	return &VoiceListParamsBuilder{}
+}
+
+// Skip sets the number of voices to skip.
+func (b *VoiceListParamsBuilder) Skip(n int) *VoiceListParamsBuilder {
+	b.params.Skip = n
+	return b
+}
+
+// Limit sets the maximum number of voices to return.
+func (b *VoiceListParamsBuilder) Limit(n int) *VoiceListParamsBuilder {
+	b.params.Limit = n
+	return b
+}
+
+// IncludeCatalog includes catalog voices in the results.
+func (b *VoiceListParamsBuilder) IncludeCatalog() *VoiceListParamsBuilder {
+	b.params.IncludeCatalog = true
+	return b
+}
+
+// Language filters voices by language.
+func (b *VoiceListParamsBuilder) Language(lang string) *VoiceListParamsBuilder {
+	b.params.Language = &lang
+	return b
+}
+
+// Name filters voices by name.
+func (b *VoiceListParamsBuilder) Name(name string) *VoiceListParamsBuilder {
+	b.params.Name = &name
+	return b
+}
+
+// Cursor resumes listing from a cursor returned by a previous page.
+func (b *VoiceListParamsBuilder) Cursor(cursor string) *VoiceListParamsBuilder {
+	b.params.Cursor = &cursor
+	return b
+}
+
+// Build returns the configured VoiceListParams.
+func (b *VoiceListParamsBuilder) Build() *VoiceListParams {
+	params := b.params
+	return &params
 }
 
 // CreditsSummary contains credit balance information.
@@ -78,32 +218,228 @@ type CreditsSummary struct {
 	PlanName         string  `json:"plan_name"`
 }
 
+// CreditsUsed returns the number of allocated credits consumed so far.
+// It never returns a negative value, even when top-ups leave more credits
+// remaining than were allocated.
+func (c CreditsSummary) CreditsUsed() int {
+	if used := c.AllocatedCredits - c.RemainingCredits; used > 0 {
+		return used
+	}
+	return 0
+}
+
+// IsExhausted reports whether no credits remain.
+func (c CreditsSummary) IsExhausted() bool {
+	return c.RemainingCredits <= 0
+}
+
+// CostEstimate is the expected credit cost of a TTS request, returned by
+// CreditsService.EstimateCost.
+type CostEstimate struct {
+	Credits    int `json:"credits"`
+	TextLength int `json:"text_length"`
+}
+
 // TTSParams contains parameters for TTS requests.
 type TTSParams struct {
 	VoiceID      string       `json:"voice_id"`
 	OutputFormat OutputFormat `json:"output_format"`
 	ModelName    string       `json:"model_name,omitempty"`
-	Text         string       `json:"-"` // Not sent in setup message
+	Language     string       `json:"language,omitempty"` // e.g. "en", sent only when set
+	Locale       string       `json:"locale,omitempty"`   // e.g. "en-US", sent only when set
+	Text         string       `json:"-"`                  // Not sent in setup message
 	JSONConfig   *TTSConfig   `json:"json_config,omitempty"`
+
+	// MaxChunkBytes caps the size of each text message sent by
+	// TTSService.Create, overriding the client's limit (4096 bytes unless set
+	// with WithTTSTextSplitter). Zero keeps the client's limit; a negative
+	// value sends the text as a single message.
+	MaxChunkBytes int `json:"-"`
+
+	// IsSSML marks Text, and text later passed to TTSStream.SendText, as SSML.
+	// It is checked with ValidateSSML before anything is sent, and
+	// TTSService.Create sends it as a single message rather than splitting it,
+	// since a split could fall inside a tag.
+	IsSSML bool `json:"-"`
 }
 
 // TTSConfig contains advanced TTS configuration.
 type TTSConfig struct {
 	// Speed control: negative = faster (-4.0 to -0.1), positive = slower (0.1 to 4.0)
 	PaddingBonus float64 `json:"padding_bonus,omitempty"`
+
+	// Speed adjusts the speaking rate, from -1.0 (slowest) to 1.0 (fastest).
+	// Zero keeps the voice's natural rate.
+	Speed float64 `json:"speed,omitempty"`
+
+	// Pitch shifts the voice, from -1.0 (lowest) to 1.0 (highest). Zero
+	// keeps the voice's natural pitch.
+	Pitch float64 `json:"pitch,omitempty"`
+
+	// Temperature controls sampling variety, from 0.0 to 2.0. Nil uses the
+	// server default.
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// Extra holds additional json_config keys not yet covered by typed
+	// fields. Typed fields take precedence on key collision.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// validate returns a ValidationError listing every field of c that is out
+// of range.
+func (c *TTSConfig) validate() error {
+	var details []ValidationErrorDetail
+	check := func(field string, v, lo, hi float64) {
+		if !(v >= lo && v <= hi) {
+			details = append(details, ValidationErrorDetail{
+				Msg: fmt.Sprintf("%s must be between %g and %g", field, lo, hi),
+				Loc: []interface{}{"json_config", field},
+			})
+		}
+	}
+	check("speed", c.Speed, -1, 1)
+	check("pitch", c.Pitch, -1, 1)
+	if c.Temperature != nil {
+		check("temperature", *c.Temperature, 0, 2)
+	}
+	if len(details) > 0 {
+		return &ValidationError{Errors: details}
+	}
+	return nil
+}
+
+// TTSUnknownEvent is sent on TTSStream.Unknown for messages whose type the
+// SDK does not recognise.
+type TTSUnknownEvent struct {
+	Type string          // The message "type" field
+	Raw  json.RawMessage // The complete message as received
 }
 
 // TTSResult contains the result of a TTS request.
 type TTSResult struct {
 	RawData    []byte
 	SampleRate int
+	Channels   int          // Always 1: the API produces mono audio
+	Format     OutputFormat // The OutputFormat RawData is encoded in
 	RequestID  string
 }
 
+// BatchOptions configures TTSService.CreateBatch.
+type BatchOptions struct {
+	// MaxConcurrent limits the number of streams open at once. Zero runs
+	// every input concurrently.
+	MaxConcurrent int
+}
+
+// BatchTTSResult is the outcome of one input of TTSService.CreateBatch.
+// Index is the position of the input in the slice passed to CreateBatch.
+type BatchTTSResult struct {
+	Index  int
+	Result *TTSResult
+	Err    error
+}
+
+// AsyncTTSResult is the outcome of TTSService.CreateAsync.
+type AsyncTTSResult struct {
+	Result *TTSResult
+	Err    error
+}
+
 // STTParams contains parameters for STT requests.
 type STTParams struct {
 	InputFormat InputFormat `json:"input_format"`
 	ModelName   string      `json:"model_name,omitempty"`
+	// RequestWordTimestamps asks the server for per-word timing, reported in
+	// STTTextResult.Words.
+	RequestWordTimestamps bool `json:"request_word_timestamps,omitempty"`
+	// Diarization asks the server to attribute text to speakers, reported in
+	// STTTextResult.SpeakerID.
+	Diarization *DiarizationConfig `json:"diarization,omitempty"`
+	// ConfidenceThreshold routes text results whose Confidence is below it to
+	// STTStream.DiscardedText instead of Text. It is also sent to the server,
+	// which may apply it itself. Results without a confidence are kept.
+	ConfidenceThreshold *float64 `json:"confidence_threshold,omitempty"`
+	// ValidateAudio makes STTStream.SendAudio reject, with an
+	// AudioValidationError, chunks that are not a whole number of frames or
+	// that exceed MaxAudioChunkBytes. It is checked client-side only.
+	// Transcribe and the other one-shot methods frame the audio themselves
+	// and are not affected.
+	ValidateAudio bool `json:"-"`
+	// Hotwords boosts the recognition of domain-specific terms. At most
+	// MaxHotwords may be given.
+	Hotwords []Hotword `json:"hotwords,omitempty"`
+	// Language hints the spoken language as an ISO 639-1 code, such as
+	// "fr". It cannot be combined with AutoDetectLanguage.
+	Language *string `json:"language,omitempty"`
+	// AutoDetectLanguage asks the server to detect the spoken language,
+	// reported in STTReadyInfo.DetectedLanguage and, for multilingual audio,
+	// STTTextResult.Language. It cannot be combined with Language.
+	AutoDetectLanguage bool `json:"auto_detect_language,omitempty"`
+}
+
+// MaxHotwords is the largest number of STTParams.Hotwords accepted.
+const MaxHotwords = 100
+
+// Hotword is a term the recognizer should favour, such as a product name.
+type Hotword struct {
+	Word string `json:"word"`
+	// Boost is how strongly to favour Word, from 0 to 10.
+	Boost float64 `json:"boost"`
+}
+
+// validateHotwords returns a ValidationError if there are more than
+// MaxHotwords hotwords or any of them is empty or has a Boost out of range.
+func validateHotwords(hotwords []Hotword) error {
+	if len(hotwords) > MaxHotwords {
+		msg := fmt.Sprintf("at most %d hotwords are allowed, got %d", MaxHotwords, len(hotwords))
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: msg, Loc: []interface{}{"hotwords"}}}}
+	}
+	var details []ValidationErrorDetail
+	for i, h := range hotwords {
+		if h.Word == "" {
+			details = append(details, ValidationErrorDetail{Msg: "word is required", Loc: []interface{}{"hotwords", i, "word"}})
+		}
+		if !(h.Boost >= 0 && h.Boost <= 10) {
+			details = append(details, ValidationErrorDetail{Msg: "boost must be between 0 and 10", Loc: []interface{}{"hotwords", i, "boost"}})
+		}
+	}
+	if len(details) > 0 {
+		return &ValidationError{Errors: details}
+	}
+	return nil
+}
+
+// DiarizationConfig configures speaker diarization. Zero fields leave the
+// speaker count to the server.
+type DiarizationConfig struct {
+	MinSpeakers int `json:"min_speakers,omitempty"`
+	MaxSpeakers int `json:"max_speakers,omitempty"`
+}
+
+// ValidateModelName returns a ValidationError if ModelName is set and is not
+// one of KnownSTTModels. An empty ModelName is valid and selects the
+// client's default model.
+//
+// The SDK may lag behind the server's model catalog, so STTService.Stream
+// does not call this; treat a failure as a warning about a likely typo.
+func (p STTParams) ValidateModelName() error {
+	if p.ModelName == "" {
+		return nil
+	}
+	for _, name := range KnownSTTModels {
+		if p.ModelName == name {
+			return nil
+		}
+	}
+
+	msg := fmt.Sprintf("unknown model name %q", p.ModelName)
+	for _, name := range KnownSTTModels {
+		if strings.EqualFold(p.ModelName, name) {
+			msg += fmt.Sprintf(", did you mean %q?", name)
+			break
+		}
+	}
+	return &ValidationError{Errors: []ValidationErrorDetail{{Msg: msg, Loc: []interface{}{"model_name"}}}}
 }
 
 // STTReadyInfo contains information sent when STT is ready.
@@ -114,13 +450,68 @@ type STTReadyInfo struct {
 	FrameSize       int      `json:"frame_size"`
 	DelayInTokens   int      `json:"delay_in_tokens"`
 	TextStreamNames []string `json:"text_stream_names"`
+	// DetectedLanguage is the ISO 639-1 code of the language detected with
+	// STTParams.AutoDetectLanguage, or nil if the server did not report one.
+	DetectedLanguage *string `json:"detected_language,omitempty"`
 }
 
 // STTTextResult contains a transcription result.
 type STTTextResult struct {
-	Text     string  `json:"text"`
-	StartS   float64 `json:"start_s"`
-	StreamID *int    `json:"stream_id,omitempty"`
+	Text      string  `json:"text"`
+	StartS    float64 `json:"start_s"`
+	StreamID  *int    `json:"stream_id,omitempty"`
+	SpeakerID *int    `json:"speaker_id,omitempty"` // Set only when the server diarises
+	// Confidence is the model's confidence in Text, between 0 and 1. It is
+	// nil when the server does not report one.
+	Confidence *float64 `json:"confidence,omitempty"`
+	// Language is the ISO 639-1 code of the language of Text when the
+	// server reports it per segment, as in multilingual streams.
+	Language *string `json:"language,omitempty"`
+
+	// Words holds per-word timing when STTParams.RequestWordTimestamps is set
+	// and the server supports it; otherwise it is nil.
+	Words []WordTimestamp `json:"words,omitempty"`
+}
+
+// WordTimestamp is a single transcribed word with its timing, as found in
+// STTTextResult.Words and Utterance.Words.
+type WordTimestamp struct {
+	Text   string  `json:"text"`
+	StartS float64 `json:"start_s"`
+	StopS  float64 `json:"stop_s"`
+	// Confidence is the model's confidence in Text, between 0 and 1. It is
+	// nil when the server does not report one.
+	Confidence *float64 `json:"confidence,omitempty"`
+}
+
+// Utterance is a run of consecutive words from the same speaker, as returned
+// by STTStream.CollectUtterances. It marshals to JSON so transcripts can be
+// written to disk directly.
+type Utterance struct {
+	Text      string          `json:"text"`
+	StartS    float64         `json:"start_s"`
+	StopS     float64         `json:"stop_s"`
+	SpeakerID *int            `json:"speaker_id,omitempty"`
+	Words     []WordTimestamp `json:"words,omitempty"`
+}
+
+// SpeakerSegment is a run of consecutive text from one speaker, as returned
+// by STTStream.CollectDiarizedTranscript.
+type SpeakerSegment struct {
+	Text      string  `json:"text"`
+	StartS    float64 `json:"start_s"`
+	EndS      float64 `json:"end_s"`
+	SpeakerID int     `json:"speaker_id"`
+}
+
+// STTSegment is a timed span of transcript, as formatted by FormatSRT and
+// FormatVTT and returned by STTStream.CollectSegments.
+type STTSegment struct {
+	Text      string  `json:"text"`
+	StartS    float64 `json:"start_s"`
+	EndS      float64 `json:"end_s"` // -1 if the end is unknown
+	SpeakerID *int    `json:"speaker_id,omitempty"`
+	StreamID  int     `json:"stream_id"` // Index of the text stream
 }
 
 // VADPrediction contains voice activity detection prediction.
@@ -143,6 +534,13 @@ type STTEndTextResult struct {
 	StreamID *int    `json:"stream_id,omitempty"`
 }
 
+// STTUnknownEvent is sent on STTStream.All for messages whose type the SDK
+// does not recognise, such as ones added to the server after this release.
+type STTUnknownEvent struct {
+	Type string          // The message "type" field
+	Raw  json.RawMessage // The complete message as received
+}
+
 // WebSocket message types
 
 type wsMessage struct {
@@ -154,12 +552,15 @@ type ttsSetupMessage struct {
 	VoiceID      string                 `json:"voice_id"`
 	OutputFormat OutputFormat           `json:"output_format"`
 	ModelName    string                 `json:"model_name"`
+	Language     string                 `json:"language,omitempty"`
+	Locale       string                 `json:"locale,omitempty"`
 	JSONConfig   map[string]interface{} `json:"json_config,omitempty"`
 }
 
 type ttsTextMessage struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Type        string `json:"type"`
+	Text        string `json:"text"`
+	ClientReqID string `json:"client_req_id,omitempty"`
 }
 
 type ttsReadyMessage struct {
@@ -168,8 +569,9 @@ type ttsReadyMessage struct {
 }
 
 type ttsAudioMessage struct {
-	Type  string `json:"type"`
-	Audio string `json:"audio"`
+	Type        string `json:"type"`
+	Audio       string `json:"audio"`
+	ClientReqID string `json:"client_req_id,omitempty"`
 }
 
 type ttsErrorMessage struct {
@@ -179,9 +581,15 @@ type ttsErrorMessage struct {
 }
 
 type sttSetupMessage struct {
-	Type        string      `json:"type"`
-	InputFormat InputFormat `json:"input_format"`
-	ModelName   string      `json:"model_name"`
+	Type                  string             `json:"type"`
+	InputFormat           InputFormat        `json:"input_format"`
+	ModelName             string             `json:"model_name"`
+	RequestWordTimestamps bool               `json:"request_word_timestamps,omitempty"`
+	Diarization           *DiarizationConfig `json:"diarization,omitempty"`
+	ConfidenceThreshold   *float64           `json:"confidence_threshold,omitempty"`
+	Hotwords              []Hotword          `json:"hotwords,omitempty"`
+	Language              *string            `json:"language,omitempty"`
+	AutoDetectLanguage    bool               `json:"auto_detect_language,omitempty"`
 }
 
 type sttAudioMessage struct {
@@ -197,13 +605,29 @@ type sttReadyMessage struct {
 	FrameSize       int      `json:"frame_size"`
 	DelayInTokens   int      `json:"delay_in_tokens"`
 	TextStreamNames []string `json:"text_stream_names"`
+
+	DetectedLanguage *string `json:"detected_language,omitempty"`
 }
 
 type sttTextMessage struct {
-	Type     string  `json:"type"`
-	Text     string  `json:"text"`
-	StartS   float64 `json:"start_s"`
-	StreamID *int    `json:"stream_id,omitempty"`
+	Type      string  `json:"type"`
+	Text      string  `json:"text"`
+	StartS    float64 `json:"start_s"`
+	StreamID  *int    `json:"stream_id,omitempty"`
+	SpeakerID *int    `json:"speaker_id,omitempty"`
+
+	Confidence *float64         `json:"confidence,omitempty"`
+	Language   *string          `json:"language,omitempty"`
+	Words      []sttWordMessage `json:"words,omitempty"`
+}
+
+// sttWordMessage is a word within a text message. The server reports its end
+// as end_s, which WordTimestamp calls StopS like the rest of the SDK.
+type sttWordMessage struct {
+	Text       string   `json:"text"`
+	StartS     float64  `json:"start_s"`
+	EndS       float64  `json:"end_s"`
+	Confidence *float64 `json:"confidence,omitempty"`
 }
 
 type sttStepMessage struct {
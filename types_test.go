@@ -2,9 +2,20 @@ package gradium
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
+func TestDefaultModelName(t *testing.T) {
+	if DefaultModelName != "default" {
+		t.Errorf("expected DefaultModelName to be 'default', got %q", DefaultModelName)
+	}
+}
+
 func TestOutputFormatConstants(t *testing.T) {
 	tests := []struct {
 		format   OutputFormat
@@ -28,6 +39,43 @@ func TestOutputFormatConstants(t *testing.T) {
 	}
 }
 
+func TestOutputFormatSampleRate(t *testing.T) {
+	tests := []struct {
+		format   OutputFormat
+		expected int
+		wantErr  bool
+	}{
+		{FormatWAV, 48000, false},
+		{FormatPCM, 48000, false},
+		{FormatOpus, 48000, false},
+		{FormatULaw8000, 8000, false},
+		{FormatALaw8000, 8000, false},
+		{FormatPCM16000, 16000, false},
+		{FormatPCM24000, 24000, false},
+		{"mp3", 48000, true},
+		{"", 48000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			if got := tt.format.SampleRate(); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+
+			rate, err := OutputFormatSampleRate(tt.format)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected a ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil || rate != tt.expected {
+				t.Errorf("expected %d, got %d (err %v)", tt.expected, rate, err)
+			}
+		})
+	}
+}
+
 func TestInputFormatConstants(t *testing.T) {
 	tests := []struct {
 		format   InputFormat
@@ -153,6 +201,54 @@ func TestCreditsSummaryJSONUnmarshal(t *testing.T) {
 	}
 }
 
+func TestCreditsSummaryHelpers(t *testing.T) {
+	tests := []struct {
+		name          string
+		summary       CreditsSummary
+		wantUsed      int
+		wantExhausted bool
+	}{
+		{
+			name:     "partially used",
+			summary:  CreditsSummary{RemainingCredits: 1000, AllocatedCredits: 5000},
+			wantUsed: 4000,
+		},
+		{
+			name:     "unused",
+			summary:  CreditsSummary{RemainingCredits: 5000, AllocatedCredits: 5000},
+			wantUsed: 0,
+		},
+		{
+			name:     "topped up",
+			summary:  CreditsSummary{RemainingCredits: 6000, AllocatedCredits: 5000},
+			wantUsed: 0,
+		},
+		{
+			name:          "exhausted",
+			summary:       CreditsSummary{RemainingCredits: 0, AllocatedCredits: 5000},
+			wantUsed:      5000,
+			wantExhausted: true,
+		},
+		{
+			name:          "overdrawn",
+			summary:       CreditsSummary{RemainingCredits: -10, AllocatedCredits: 5000},
+			wantUsed:      5010,
+			wantExhausted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.summary.CreditsUsed(); got != tt.wantUsed {
+				t.Errorf("expected CreditsUsed %d, got %d", tt.wantUsed, got)
+			}
+			if got := tt.summary.IsExhausted(); got != tt.wantExhausted {
+				t.Errorf("expected IsExhausted %v, got %v", tt.wantExhausted, got)
+			}
+		})
+	}
+}
+
 func TestTTSParamsJSONMarshal(t *testing.T) {
 	params := TTSParams{
 		VoiceID:      "voice-123",
@@ -208,6 +304,39 @@ func TestSTTParamsJSONMarshal(t *testing.T) {
 	}
 }
 
+func TestSTTParamsValidateModelName(t *testing.T) {
+	tests := []struct {
+		name      string
+		modelName string
+		wantErr   string
+	}{
+		{name: "empty", modelName: ""},
+		{name: "default", modelName: "default"},
+		{name: "whisper", modelName: "whisper"},
+		{name: "wrong case", modelName: "Whisper", wantErr: `validation error: unknown model name "Whisper", did you mean "whisper"?`},
+		{name: "unknown", modelName: "nope", wantErr: `validation error: unknown model name "nope"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := STTParams{ModelName: tt.modelName}.ValidateModelName()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
 func TestSTTReadyInfoJSONUnmarshal(t *testing.T) {
 	jsonData := `{
 		"request_id": "req-123",
@@ -410,10 +539,92 @@ func TestTTSConfigJSONMarshal(t *testing.T) {
 	}
 }
 
+func TestTTSConfigSetupConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TTSConfig
+		want   map[string]interface{}
+	}{
+		{
+			name:   "padding bonus only",
+			config: TTSConfig{PaddingBonus: -0.5},
+			want:   map[string]interface{}{"padding_bonus": -0.5},
+		},
+		{
+			name:   "all fields",
+			config: TTSConfig{PaddingBonus: 1, Speed: 0.5, Pitch: -0.25, Temperature: float64Ptr(0)},
+			want:   map[string]interface{}{"padding_bonus": 1.0, "speed": 0.5, "pitch": -0.25, "temperature": 0.0},
+		},
+		{
+			name:   "typed fields override extra",
+			config: TTSConfig{Speed: 0.5, Extra: map[string]interface{}{"speed": -1.0, "new_feature": true}},
+			want:   map[string]interface{}{"speed": 0.5, "new_feature": true},
+		},
+		{
+			name:   "empty",
+			config: TTSConfig{},
+			want:   map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.setupConfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTTSConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TTSConfig
+		wantLoc []string
+	}{
+		{name: "zero value", config: TTSConfig{}},
+		{name: "bounds", config: TTSConfig{Speed: -1, Pitch: 1, Temperature: float64Ptr(2)}},
+		{name: "speed too high", config: TTSConfig{Speed: 1.5}, wantLoc: []string{"speed"}},
+		{name: "pitch too low", config: TTSConfig{Pitch: -2}, wantLoc: []string{"pitch"}},
+		{name: "speed NaN", config: TTSConfig{Speed: math.NaN()}, wantLoc: []string{"speed"}},
+		{name: "temperature negative", config: TTSConfig{Temperature: float64Ptr(-0.1)}, wantLoc: []string{"temperature"}},
+		{name: "several fields", config: TTSConfig{Speed: 2, Pitch: 2}, wantLoc: []string{"speed", "pitch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.validate()
+			if len(tt.wantLoc) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			var locs []string
+			for _, d := range valErr.Errors {
+				locs = append(locs, d.Loc[1].(string))
+			}
+			if !reflect.DeepEqual(locs, tt.wantLoc) {
+				t.Errorf("expected errors for %v, got %v", tt.wantLoc, locs)
+			}
+		})
+	}
+}
+
 func TestTTSResultFields(t *testing.T) {
 	result := TTSResult{
 		RawData:    []byte("test audio data"),
 		SampleRate: 48000,
+		Channels:   1,
+		Format:     FormatWAV,
 		RequestID:  "req-123",
 	}
 
@@ -423,6 +634,9 @@ func TestTTSResultFields(t *testing.T) {
 	if result.SampleRate != 48000 {
 		t.Errorf("expected SampleRate 48000, got %d", result.SampleRate)
 	}
+	if result.Channels != 1 || result.Format != FormatWAV {
+		t.Errorf("expected mono wav, got %d channels of %q", result.Channels, result.Format)
+	}
 	if result.RequestID != "req-123" {
 		t.Errorf("expected RequestID 'req-123', got %q", result.RequestID)
 	}
@@ -444,6 +658,30 @@ func TestVoiceCreateResponseJSONUnmarshal(t *testing.T) {
 				if resp.WasUpdated != false {
 					t.Error("WasUpdated should be false")
 				}
+				if resp.Voice != nil {
+					t.Error("Voice should be nil when absent")
+				}
+			},
+		},
+		{
+			name:     "with inline voice",
+			jsonData: `{"uid": "voice-123", "was_updated": false, "voice": {"uid": "voice-123", "name": "My Voice", "language": "en", "start_s": 0.5, "filename": "sample.wav"}}`,
+			checkFn: func(t *testing.T, resp VoiceCreateResponse) {
+				if resp.Voice == nil {
+					t.Fatal("expected Voice to be set")
+				}
+				if resp.Voice.UID != "voice-123" {
+					t.Errorf("expected Voice.UID 'voice-123', got %q", resp.Voice.UID)
+				}
+				if resp.Voice.Name != "My Voice" {
+					t.Errorf("expected Voice.Name 'My Voice', got %q", resp.Voice.Name)
+				}
+				if resp.Voice.Language == nil || *resp.Voice.Language != "en" {
+					t.Error("Voice.Language mismatch")
+				}
+				if resp.Voice.StartS != 0.5 {
+					t.Errorf("expected Voice.StartS 0.5, got %f", resp.Voice.StartS)
+				}
 			},
 		},
 		{
@@ -554,3 +792,60 @@ func TestSTTSetupMessageJSONMarshal(t *testing.T) {
 		t.Errorf("expected model_name 'whisper', got %v", parsed["model_name"])
 	}
 }
+
+func TestHotwordsJSONMarshal(t *testing.T) {
+	msg := sttSetupMessage{
+		Type:        "setup",
+		InputFormat: InputFormatPCM,
+		Hotwords:    []Hotword{{Word: "Kubernetes", Boost: 5}, {Word: "OAuth2", Boost: 0}},
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var parsed struct {
+		Hotwords []map[string]interface{} `json:"hotwords"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	want := []map[string]interface{}{
+		{"word": "Kubernetes", "boost": 5.0},
+		{"word": "OAuth2", "boost": 0.0},
+	}
+	if !reflect.DeepEqual(parsed.Hotwords, want) {
+		t.Errorf("expected hotwords %v, got %v", want, parsed.Hotwords)
+	}
+
+	data, _ = json.Marshal(sttSetupMessage{Type: "setup"})
+	if strings.Contains(string(data), "hotwords") {
+		t.Errorf("hotwords should be omitted when empty, got %s", data)
+	}
+}
+
+func TestValidateHotwords(t *testing.T) {
+	tests := []struct {
+		name     string
+		hotwords []Hotword
+		wantErr  bool
+	}{
+		{name: "none", hotwords: nil},
+		{name: "bounds", hotwords: []Hotword{{Word: "a", Boost: 0}, {Word: "b", Boost: 10}}},
+		{name: "maximum count", hotwords: slices.Repeat([]Hotword{{Word: "a", Boost: 1}}, MaxHotwords)},
+		{name: "too many", hotwords: make([]Hotword, MaxHotwords+1), wantErr: true},
+		{name: "boost too high", hotwords: []Hotword{{Word: "a", Boost: 10.5}}, wantErr: true},
+		{name: "boost negative", hotwords: []Hotword{{Word: "a", Boost: -1}}, wantErr: true},
+		{name: "empty word", hotwords: []Hotword{{Boost: 1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHotwords(tt.hotwords)
+			if tt.wantErr != errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
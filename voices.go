@@ -4,82 +4,338 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// maxVoiceNameLen is the longest voice name, in bytes, accepted by the API.
+const maxVoiceNameLen = 255
+
+// maxPreviewTextLen is the longest preview text, in characters, accepted by
+// VoicesService.Preview.
+const maxPreviewTextLen = 200
+
+// defaultVoicesBatchConcurrency is the number of requests DeleteBatch and
+// UpdateBatch run at a time unless WithVoicesBatchConcurrency is used.
+const defaultVoicesBatchConcurrency = 5
+
 // VoicesService handles voice management operations.
 type VoicesService struct {
-	client *Client
+	client           *Client
+	timeout          time.Duration
+	batchConcurrency int
 }
 
-// List returns all voices for the authenticated organization.
+// List returns all voices for the authenticated organization. It returns
+// only the voices in the response; use ListPage to learn whether more pages
+// exist.
 func (s *VoicesService) List(ctx context.Context, params *VoiceListParams) ([]Voice, error) {
-	url := s.client.baseURL + "/voices/"
+	page, err := s.ListPage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return page.Voices, nil
+}
+
+// Search returns every voice matching query by name, following pagination
+// until the last page. The API has no dedicated search endpoint, so this is
+// List with the VoiceListParams.Name filter.
+//
+// Example:
+//
+//	voices, err := client.Voices.Search(ctx, "Narrator")
+func (s *VoicesService) Search(ctx context.Context, query string) ([]Voice, error) {
+	var voices []Voice
+	it := s.Iter(ctx, &VoiceListParams{Name: &query})
+	for it.Next() {
+		voices = append(voices, *it.Voice())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return voices, nil
+}
+
+// GetByName returns the voice whose name is exactly name. It searches every
+// page with Search, so a voice is only reported missing, with a
+// NotFoundError, once all pages have been checked. If several voices share
+// the name it returns an error, since the result would be ambiguous; use Get
+// with a UID instead.
+func (s *VoicesService) GetByName(ctx context.Context, name string) (*Voice, error) {
+	voices, err := s.Search(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return exactVoiceMatch(voices, name)
+}
+
+// exactVoiceMatch returns the only voice in voices named name.
+func exactVoiceMatch(voices []Voice, name string) (*Voice, error) {
+	var match *Voice
+	for i := range voices {
+		if voices[i].Name != name {
+			continue
+		}
+		if match != nil {
+			return nil, &Error{Message: fmt.Sprintf("multiple voices found with name %s; use Get(uid) instead", name)}
+		}
+		match = &voices[i]
+	}
+	if match == nil {
+		return nil, &NotFoundError{Message: fmt.Sprintf("no voice found with name %s", name)}
+	}
+	return match, nil
+}
+
+// ListPage returns one page of voices along with pagination metadata. The API
+// answers 206 Partial Content with a Content-Range header such as
+// "voices 0-9/1000" when more voices exist than were returned.
+func (s *VoicesService) ListPage(ctx context.Context, params *VoiceListParams) (*VoiceListResponse, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return retry(ctx, s.client, func() (*VoiceListResponse, error) {
+		return s.listPage(ctx, params)
+	})
+}
+
+// listPage makes a single ListPage request.
+func (s *VoicesService) listPage(ctx context.Context, params *VoiceListParams) (*VoiceListResponse, error) {
+	reqURL := s.client.baseURL + "/voices/"
 
 	if params != nil {
-		query := "?"
+		query := url.Values{}
 		if params.Skip > 0 {
-			query += "skip=" + strconv.Itoa(params.Skip) + "&"
+			query.Set("skip", strconv.Itoa(params.Skip))
 		}
 		if params.Limit > 0 {
-			query += "limit=" + strconv.Itoa(params.Limit) + "&"
+			query.Set("limit", strconv.Itoa(params.Limit))
 		}
 		if params.IncludeCatalog {
-			query += "include_catalog=true&"
+			query.Set("include_catalog", "true")
 		}
-		if len(query) > 1 {
-			url += query[:len(query)-1] // Remove trailing &
+		if params.Language != nil {
+			query.Set("language", *params.Language)
+		}
+		if params.Name != nil {
+			query.Set("name", *params.Name)
+		}
+		if params.Cursor != nil {
+			query.Set("cursor", *params.Cursor)
+		}
+		if len(query) > 0 {
+			reqURL += "?" + query.Encode()
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("x-api-key", s.client.apiKey)
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
 
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
-		return nil, &ConnectionError{Message: err.Error()}
+		return nil, requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if err := gunzipBody(resp); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
 		return nil, handleAPIError(resp)
 	}
 
-	var voices []Voice
-	if err := json.NewDecoder(resp.Body).Decode(&voices); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return nil, err
 	}
 
-	return voices, nil
+	var list voiceListPage
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		err = json.Unmarshal(body, &list.Items)
+	} else {
+		err = json.Unmarshal(body, &list)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := &VoiceListResponse{Voices: list.Items, Total: len(list.Items), NextCursor: list.NextCursor}
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		page.Total, page.HasMore = parseContentRange(resp.Header.Get("Content-Range"))
+	case list.NextCursor != nil:
+		page.Total, page.HasMore = -1, true
+	}
+
+	return page, nil
+}
+
+// Iter returns an iterator over all voices matching params, fetching pages
+// lazily as it advances. It follows next cursors when the server sends them,
+// and otherwise advances Skip past each 206 Partial Content page whose
+// Content-Range reports more voices. Pagination stops at the first page with
+// neither.
+//
+// Example:
+//
+//	it := client.Voices.Iter(ctx, &gradium.VoiceListParams{Limit: 100})
+//	for it.Next() {
+//	    fmt.Println(it.Voice().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (s *VoicesService) Iter(ctx context.Context, params *VoiceListParams) *VoiceIter {
+	return newVoiceIter(ctx, params, s.ListPage)
+}
+
+// newVoiceIter returns a VoiceIter that fetches pages with listPage.
+func newVoiceIter(ctx context.Context, params *VoiceListParams, listPage func(context.Context, *VoiceListParams) (*VoiceListResponse, error)) *VoiceIter {
+	it := &VoiceIter{listPage: listPage, ctx: ctx}
+	if params != nil {
+		it.params = *params
+	}
+	return it
+}
+
+// VoiceIter iterates over voices page by page. It is not safe for
+// concurrent use.
+type VoiceIter struct {
+	listPage func(context.Context, *VoiceListParams) (*VoiceListResponse, error)
+	ctx      context.Context
+	params   VoiceListParams
+	page     []Voice
+	index    int
+	done     bool
+	err      error
+}
+
+// Next advances to the next voice, fetching a page if needed. It returns
+// false when the voices are exhausted or an error occurs; check Err.
+func (it *VoiceIter) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetchPage()
+	}
+	it.index++
+	return true
+}
+
+// fetchPage loads the page at the iterator's cursor.
+func (it *VoiceIter) fetchPage() {
+	page, err := it.listPage(it.ctx, &it.params)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.page, it.index = page.Voices, 0
+
+	switch {
+	case page.NextCursor != nil:
+		// The cursor already encodes the position, so Skip applies only once
+		it.params.Cursor = page.NextCursor
+		it.params.Skip = 0
+	case page.HasMore && len(page.Voices) > 0:
+		// Offset pagination: the next page starts after this one
+		it.params.Skip += len(page.Voices)
+	default:
+		it.done = true
+	}
+}
+
+// Voice returns the current voice. It is valid only after Next returns true.
+func (it *VoiceIter) Voice() *Voice {
+	if it.index == 0 || it.index > len(it.page) {
+		return nil
+	}
+	return &it.page[it.index-1]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *VoiceIter) Err() error {
+	return it.err
+}
+
+// parseContentRange parses a Content-Range header of the form
+// "voices <first>-<last>/<total>" from a 206 response. It returns the total,
+// or -1 if it is missing or "*", and whether voices after <last> exist; when
+// the range cannot be parsed more voices are assumed.
+func parseContentRange(header string) (total int, hasMore bool) {
+	_, rangeSpec, _ := strings.Cut(header, " ")
+	span, size, ok := strings.Cut(rangeSpec, "/")
+	if !ok {
+		return -1, true
+	}
+	total, err := strconv.Atoi(size)
+	if err != nil {
+		return -1, true
+	}
+	_, last, ok := strings.Cut(span, "-")
+	if !ok {
+		return total, true
+	}
+	end, err := strconv.Atoi(last)
+	if err != nil {
+		return total, true
+	}
+	return total, end+1 < total
 }
 
 // Get returns a specific voice by its UID.
 func (s *VoicesService) Get(ctx context.Context, voiceUID string) (*Voice, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return retry(ctx, s.client, func() (*Voice, error) {
+		return s.get(ctx, voiceUID)
+	})
+}
+
+// get makes a single Get request.
+func (s *VoicesService) get(ctx context.Context, voiceUID string) (*Voice, error) {
 	url := s.client.baseURL + "/voices/" + voiceUID
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("x-api-key", s.client.apiKey)
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
 
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
-		return nil, &ConnectionError{Message: err.Error()}
+		return nil, requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if err := gunzipBody(resp); err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, handleAPIError(resp)
 	}
@@ -93,100 +349,422 @@ func (s *VoicesService) Get(ctx context.Context, voiceUID string) (*Voice, error
 }
 
 // Create creates a new custom voice from an audio file.
+// If params.InputFormat is empty it is derived from the filename extension
+// (.wav, .pcm or .opus).
+// A ValidationError is returned without calling the API if filename or
+// params.Name is empty, or if params.Name is longer than 255 bytes.
+//
+// The client timeout (30s by default) covers the whole upload, so raise it
+// with WithTimeout when uploading large audio files; a request that runs out
+// of time fails with a TimeoutError.
 func (s *VoicesService) Create(ctx context.Context, audioData io.Reader, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error) {
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := validateVoiceCreate(filename, params); err != nil {
+		return nil, err
+	}
+
+	// The upload can only be replayed if the audio can be rewound
+	seeker, ok := audioData.(io.Seeker)
+	if !ok {
+		return s.create(ctx, audioData, filename, params)
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return s.create(ctx, audioData, filename, params)
+	}
+
+	return retry(ctx, s.client, func() (*VoiceCreateResponse, error) {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return s.create(ctx, audioData, filename, params)
+	})
+}
+
+// CreateFromURL creates a new custom voice from audio hosted at audioURL,
+// such as a presigned S3 URL, without the caller downloading it first. The
+// server is first asked to fetch the audio itself. If it rejects the request
+// because it does not support remote URLs (a 422 about the audio_url or
+// audio_file field), the audio is instead downloaded and streamed into
+// Create as it arrives, so it is never held in memory. Any other error,
+// including other validation errors, is returned as is. filename and params
+// are as for Create; the filename still selects the input format when
+// params.InputFormat is empty.
+//
+// The download uses a plain HTTP client rather than the client's own, so
+// neither the API key nor middleware, rate limiting or request logging
+// apply to the third-party URL. The client timeout covers both the download
+// and the upload, which run concurrently, and a failed upload is not retried
+// since the download cannot be rewound.
+//
+// Example:
+//
+//	resp, err := client.Voices.CreateFromURL(ctx, presignedURL, "sample.wav", gradium.VoiceCreateParams{
+//	    Name: "My Voice",
+//	})
+func (s *VoicesService) CreateFromURL(ctx context.Context, audioURL, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if audioURL == "" {
+		return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "audio URL is required", Loc: []interface{}{"audio_url"}}}}
+	}
+	if err := validateVoiceCreate(filename, params); err != nil {
+		return nil, err
+	}
+
+	result, err := retry(ctx, s.client, func() (*VoiceCreateResponse, error) {
+		return s.createFromURL(ctx, audioURL, filename, params)
+	})
+	if !remoteURLUnsupported(err) {
+		return result, err
+	}
+
+	audio, err := s.download(ctx, audioURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = audio.Close() }()
+
+	return s.create(ctx, audio, filename, params)
+}
+
+// remoteURLUnsupported reports whether err is the 422 a server without
+// remote URL support returns for a CreateFromURL request: a validation error
+// on the audio_url field it does not recognise, or on the audio_file field it
+// still requires.
+func remoteURLUnsupported(err error) bool {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Status != http.StatusUnprocessableEntity {
+		return false
+	}
+	for _, detail := range validationErr.Errors {
+		for _, loc := range detail.Loc {
+			if loc == "audio_url" || loc == "audio_file" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// voiceCreateURLRequest is the JSON body of a CreateFromURL request.
+type voiceCreateURLRequest struct {
+	AudioURL    string  `json:"audio_url"`
+	Name        string  `json:"name"`
+	InputFormat string  `json:"input_format,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Language    *string `json:"language,omitempty"`
+	StartS      float64 `json:"start_s,omitempty"`
+	TimeoutS    float64 `json:"timeout_s,omitempty"`
+}
+
+// createFromURL makes a single request asking the server to fetch the audio
+// for a new voice from audioURL.
+func (s *VoicesService) createFromURL(ctx context.Context, audioURL, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error) {
+	inputFormat := params.InputFormat
+	if inputFormat == "" {
+		inputFormat = inputFormatFromFilename(filename)
+	}
+	body, err := json.Marshal(voiceCreateURLRequest{
+		AudioURL:    audioURL,
+		Name:        params.Name,
+		InputFormat: inputFormat,
+		Description: params.Description,
+		Language:    params.Language,
+		StartS:      params.StartS,
+		TimeoutS:    params.TimeoutS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodPost, s.client.baseURL+"/voices/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, handleAPIError(resp)
+	}
+
+	var result VoiceCreateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// download starts a GET of audioURL and returns the response body for the
+// caller to stream and close. It uses a plain HTTP client so that nothing
+// configured for the Gradium API reaches the third-party host.
+func (s *VoicesService) download(ctx context.Context, audioURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, audioURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: s.client.timeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, &Error{Message: "failed to download audio: " + resp.Status}
+	}
+
+	return resp.Body, nil
+}
+
+// validateVoiceCreate checks the arguments of VoicesService.Create that the
+// SDK can verify without calling the API.
+func validateVoiceCreate(filename string, params VoiceCreateParams) error {
+	if filename == "" {
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "filename is required", Loc: []interface{}{"filename"}}}}
+	}
+	if params.Name == "" {
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: "name is required", Loc: []interface{}{"name"}}}}
+	}
+	if len(params.Name) > maxVoiceNameLen {
+		return nameTooLongError()
+	}
+	return nil
+}
+
+// create makes a single Create request. It returns only once audioData is
+// no longer being read.
+func (s *VoicesService) create(ctx context.Context, audioData io.Reader, filename string, params VoiceCreateParams) (*VoiceCreateResponse, error) {
+	// Stream the multipart body through a pipe so large uploads are never
+	// buffered in memory. The transport closes the reader when the request
+	// finishes or ctx is cancelled, which stops the writer goroutine.
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		_ = pw.CloseWithError(writeVoiceCreateForm(writer, audioData, filename, params))
+	}()
+	defer func() {
+		_ = pr.Close()
+		<-written
+	}()
+
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodPost, s.client.baseURL+"/voices/", pr)
+	if err != nil {
+		return nil, err
+	}
 
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, handleAPIError(resp)
+	}
+
+	var result VoiceCreateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// nameTooLongError returns the ValidationError for a voice name longer than
+// maxVoiceNameLen.
+func nameTooLongError() error {
+	msg := fmt.Sprintf("name must be at most %d bytes", maxVoiceNameLen)
+	return &ValidationError{Errors: []ValidationErrorDetail{{Msg: msg, Loc: []interface{}{"name"}}}}
+}
+
+// writeVoiceCreateForm writes the multipart form for Create to writer and
+// closes it.
+func writeVoiceCreateForm(writer *multipart.Writer, audioData io.Reader, filename string, params VoiceCreateParams) error {
 	// Add audio file
 	part, err := writer.CreateFormFile("audio_file", filename)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if _, err := io.Copy(part, audioData); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Add name
 	if err := writer.WriteField("name", params.Name); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Add optional fields
-	if params.InputFormat != "" {
-		if err := writer.WriteField("input_format", params.InputFormat); err != nil {
-			return nil, err
+	inputFormat := params.InputFormat
+	if inputFormat == "" {
+		inputFormat = inputFormatFromFilename(filename)
+	}
+	if inputFormat != "" {
+		if err := writer.WriteField("input_format", inputFormat); err != nil {
+			return err
 		}
 	}
 	if params.Description != nil {
 		if err := writer.WriteField("description", *params.Description); err != nil {
-			return nil, err
+			return err
 		}
 	}
 	if params.Language != nil {
 		if err := writer.WriteField("language", *params.Language); err != nil {
-			return nil, err
+			return err
 		}
 	}
 	if params.StartS != 0 {
 		if err := writer.WriteField("start_s", fmt.Sprintf("%f", params.StartS)); err != nil {
-			return nil, err
+			return err
 		}
 	}
 	if params.TimeoutS != 0 {
 		if err := writer.WriteField("timeout_s", fmt.Sprintf("%f", params.TimeoutS)); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	if err := writer.Close(); err != nil {
+	return writer.Close()
+}
+
+// Update updates an existing voice and returns it as stored by the server.
+// Only fields set in params are sent; nil fields are omitted from the request
+// rather than cleared. Whether omitted fields are preserved is up to the
+// server, so re-check the returned Voice if that matters.
+// A ValidationError is returned without calling the API if no field is set
+// or if params.Name is longer than 255 bytes.
+func (s *VoicesService) Update(ctx context.Context, voiceUID string, params VoiceUpdateParams) (*Voice, error) {
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if params.isEmpty() {
+		return nil, &ValidationError{Errors: []ValidationErrorDetail{{Msg: "at least one field must be set"}}}
+	}
+	if params.Name != nil && len(*params.Name) > maxVoiceNameLen {
+		return nil, nameTooLongError()
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.baseURL+"/voices/", &buf)
+	return retry(ctx, s.client, func() (*Voice, error) {
+		return s.update(ctx, voiceUID, body)
+	})
+}
+
+// update makes a single Update request with the encoded params.
+func (s *VoicesService) update(ctx context.Context, voiceUID string, body []byte) (*Voice, error) {
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodPut, s.client.baseURL+"/voices/"+voiceUID, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("x-api-key", s.client.apiKey)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
 
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
-		return nil, &ConnectionError{Message: err.Error()}
+		return nil, requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		return nil, handleAPIError(resp)
 	}
 
-	var result VoiceCreateResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	var voice Voice
+	if err := json.NewDecoder(resp.Body).Decode(&voice); err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	return &voice, nil
 }
 
-// Update updates an existing voice.
-func (s *VoicesService) Update(ctx context.Context, voiceUID string, params VoiceUpdateParams) (*Voice, error) {
-	body, err := json.Marshal(params)
+// Preview synthesizes a short clip of text, at most 200 characters, with the
+// voice voiceUID. It calls POST /voices/{uid}/preview, and falls back to
+// TTS.Create when the server has no preview endpoint (404 or 405), so the
+// result can be saved or played like any other TTSResult.
+//
+// Example:
+//
+//	clip, err := client.Voices.Preview(ctx, voiceUID, "Hello, this is my new voice.", gradium.FormatWAV)
+//	os.WriteFile("preview.wav", clip.RawData, 0644)
+func (s *VoicesService) Preview(ctx context.Context, voiceUID, text string, format OutputFormat) (*TTSResult, error) {
+	if err := validatePreview(text); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	result, err := retry(ctx, s.client, func() (*TTSResult, error) {
+		return s.preview(ctx, voiceUID, text, format)
+	})
+	var apiErr *APIError
+	if errors.Is(err, ErrNotFound) || (errors.As(err, &apiErr) && apiErr.Status == http.StatusMethodNotAllowed) {
+		return s.client.TTS.Create(ctx, TTSParams{VoiceID: voiceUID, OutputFormat: format, Text: text})
+	}
+	return result, err
+}
+
+// validatePreview checks the text passed to Preview.
+func validatePreview(text string) error {
+	if utf8.RuneCountInString(text) > maxPreviewTextLen {
+		msg := fmt.Sprintf("preview text must be at most %d characters", maxPreviewTextLen)
+		return &ValidationError{Errors: []ValidationErrorDetail{{Msg: msg, Loc: []interface{}{"text"}}}}
+	}
+	return nil
+}
+
+// preview makes a single Preview request.
+func (s *VoicesService) preview(ctx context.Context, voiceUID, text string, format OutputFormat) (*TTSResult, error) {
+	body, err := json.Marshal(map[string]string{"text": text, "output_format": string(format)})
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.client.baseURL+"/voices/"+voiceUID, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodPost, s.client.baseURL+"/voices/"+voiceUID+"/preview", bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("x-api-key", s.client.apiKey)
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
 
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
-		return nil, &ConnectionError{Message: err.Error()}
+		return nil, requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -194,26 +772,79 @@ func (s *VoicesService) Update(ctx context.Context, voiceUID string, params Voic
 		return nil, handleAPIError(resp)
 	}
 
-	var voice Voice
-	if err := json.NewDecoder(resp.Body).Decode(&voice); err != nil {
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return nil, err
 	}
+	if len(audio) == 0 {
+		return nil, &EmptyResponseError{Message: "no audio received"}
+	}
 
-	return &voice, nil
+	return newTTSResult(audio, format, resp.Header.Get("x-request-id")), nil
 }
 
 // Delete deletes a voice by its UID.
 func (s *VoicesService) Delete(ctx context.Context, voiceUID string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.client.baseURL+"/voices/"+voiceUID, nil)
+	ctx, cancel := withDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+
+	_, err := retry(ctx, s.client, func() (struct{}, error) {
+		return struct{}{}, s.remove(ctx, voiceUID)
+	})
+	return err
+}
+
+// DeleteBatch deletes each voice in uids, running up to 5 requests at a time
+// (see WithVoicesBatchConcurrency). The returned slice has one entry per
+// UID: nil if that voice was deleted, or the error from Delete otherwise. A
+// failure does not stop the remaining deletions.
+//
+// Example:
+//
+//	errs := client.Voices.DeleteBatch(ctx, uids)
+//	for i, err := range errs {
+//	    if err != nil {
+//	        log.Printf("delete %s: %v", uids[i], err)
+//	    }
+//	}
+func (s *VoicesService) DeleteBatch(ctx context.Context, uids []string) []error {
+	return forEachConcurrent(ctx, len(uids), s.batchConcurrency, func(i int) error {
+		return s.Delete(ctx, uids[i])
+	})
+}
+
+// UpdateBatch applies each update with Update, running up to 5 requests at a
+// time (see WithVoicesBatchConcurrency). Both returned slices have one entry
+// per update: on success the error is nil and the voice holds the updated
+// voice; on failure the voice is the zero Voice. A failure does not stop the
+// remaining updates.
+func (s *VoicesService) UpdateBatch(ctx context.Context, updates []VoiceBatchUpdate) ([]Voice, []error) {
+	voices := make([]Voice, len(updates))
+	errs := forEachConcurrent(ctx, len(updates), s.batchConcurrency, func(i int) error {
+		voice, err := s.Update(ctx, updates[i].UID, updates[i].VoiceUpdateParams)
+		if err != nil {
+			return err
+		}
+		voices[i] = *voice
+		return nil
+	})
+	return voices, errs
+}
+
+// remove makes a single Delete request.
+func (s *VoicesService) remove(ctx context.Context, voiceUID string) error {
+	req, err := http.NewRequestWithContext(s.client.requestContext(ctx), http.MethodDelete, s.client.baseURL+"/voices/"+voiceUID, nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("x-api-key", s.client.apiKey)
+	if err := s.client.setAPIKey(ctx, req.Header); err != nil {
+		return err
+	}
 
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
-		return &ConnectionError{Message: err.Error()}
+		return requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -223,3 +854,24 @@ func (s *VoicesService) Delete(ctx context.Context, voiceUID string) error {
 
 	return nil
 }
+
+// isEmpty reports whether no field of p is set.
+func (p VoiceUpdateParams) isEmpty() bool {
+	return p.Name == nil && p.Description == nil && p.Language == nil &&
+		p.StartS == nil && len(p.Tags) == 0 && p.Rank == nil
+}
+
+// inputFormatFromFilename returns the input format matching the filename
+// extension, or an empty string if the extension is not recognized.
+func inputFormatFromFilename(filename string) string {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".wav":
+		return string(InputFormatWAV)
+	case ".pcm":
+		return string(InputFormatPCM)
+	case ".opus":
+		return string(InputFormatOpus)
+	default:
+		return ""
+	}
+}
@@ -4,11 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestVoicesService_List(t *testing.T) {
@@ -34,7 +42,7 @@ func TestVoicesService_List(t *testing.T) {
 		{
 			name:          "list with skip and limit",
 			params:        &VoiceListParams{Skip: 10, Limit: 5},
-			expectedQuery: "skip=10&limit=5",
+			expectedQuery: "limit=5&skip=10",
 			responseCode:  http.StatusOK,
 			responseBody:  []Voice{},
 			expectedErr:   false,
@@ -50,7 +58,23 @@ func TestVoicesService_List(t *testing.T) {
 		{
 			name:          "list with all params",
 			params:        &VoiceListParams{Skip: 5, Limit: 10, IncludeCatalog: true},
-			expectedQuery: "skip=5&limit=10&include_catalog=true",
+			expectedQuery: "include_catalog=true&limit=10&skip=5",
+			responseCode:  http.StatusOK,
+			responseBody:  []Voice{},
+			expectedErr:   false,
+		},
+		{
+			name:          "list with language",
+			params:        &VoiceListParams{Language: stringPtr("pt-BR")},
+			expectedQuery: "language=pt-BR",
+			responseCode:  http.StatusOK,
+			responseBody:  []Voice{},
+			expectedErr:   false,
+		},
+		{
+			name:          "list with language needing escaping",
+			params:        &VoiceListParams{Language: stringPtr("zh Hans&x=1")},
+			expectedQuery: "language=zh+Hans%26x%3D1",
 			responseCode:  http.StatusOK,
 			responseBody:  []Voice{},
 			expectedErr:   false,
@@ -82,6 +106,11 @@ func TestVoicesService_List(t *testing.T) {
 					t.Error("missing or wrong API key header")
 				}
 
+				// Verify query string
+				if r.URL.RawQuery != tt.expectedQuery {
+					t.Errorf("expected query %q, got %q", tt.expectedQuery, r.URL.RawQuery)
+				}
+
 				w.WriteHeader(tt.responseCode)
 				json.NewEncoder(w).Encode(tt.responseBody)
 			}))
@@ -110,6 +139,256 @@ func TestVoicesService_List(t *testing.T) {
 	}
 }
 
+func TestVoicesService_ListQueryCombinations(t *testing.T) {
+	// Exercise every combination of Skip, Limit and IncludeCatalog presence
+	for mask := 0; mask < 8; mask++ {
+		// Parameters are encoded in key order
+		params := &VoiceListParams{}
+		var want []string
+		if mask&4 != 0 {
+			params.IncludeCatalog = true
+			want = append(want, "include_catalog=true")
+		}
+		if mask&2 != 0 {
+			params.Limit = 10
+			want = append(want, "limit=10")
+		}
+		if mask&1 != 0 {
+			params.Skip = 5
+			want = append(want, "skip=5")
+		}
+		name := strings.Join(want, "&")
+		expectedURI := "/voices/"
+		if name != "" {
+			expectedURI += "?" + name
+		} else {
+			name = "no params"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.RequestURI != expectedURI {
+					t.Errorf("expected request URI %q, got %q", expectedURI, r.RequestURI)
+				}
+				json.NewEncoder(w).Encode([]Voice{})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if _, err := client.Voices.List(context.Background(), params); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVoicesService_ListPage(t *testing.T) {
+	voices := []Voice{{UID: "voice-1", Name: "One"}, {UID: "voice-2", Name: "Two"}}
+
+	tests := []struct {
+		name         string
+		status       int
+		contentRange string
+		wantTotal    int
+		wantHasMore  bool
+	}{
+		{name: "complete list", status: http.StatusOK, wantTotal: 2, wantHasMore: false},
+		{name: "first page", status: http.StatusPartialContent, contentRange: "voices 0-1/1000", wantTotal: 1000, wantHasMore: true},
+		{name: "last page", status: http.StatusPartialContent, contentRange: "voices 998-999/1000", wantTotal: 1000, wantHasMore: false},
+		{name: "unknown total", status: http.StatusPartialContent, contentRange: "voices 0-1/*", wantTotal: -1, wantHasMore: true},
+		{name: "missing header", status: http.StatusPartialContent, wantTotal: -1, wantHasMore: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.contentRange != "" {
+					w.Header().Set("Content-Range", tt.contentRange)
+				}
+				w.WriteHeader(tt.status)
+				json.NewEncoder(w).Encode(voices)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+			page, err := client.Voices.ListPage(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(page.Voices) != len(voices) {
+				t.Errorf("expected %d voices, got %d", len(voices), len(page.Voices))
+			}
+			if page.Total != tt.wantTotal {
+				t.Errorf("expected Total %d, got %d", tt.wantTotal, page.Total)
+			}
+			if page.HasMore != tt.wantHasMore {
+				t.Errorf("expected HasMore %v, got %v", tt.wantHasMore, page.HasMore)
+			}
+
+			// List treats a partial page as success too
+			list, err := client.Voices.List(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error from List: %v", err)
+			}
+			if len(list) != len(voices) {
+				t.Errorf("expected List to return %d voices, got %d", len(voices), len(list))
+			}
+		})
+	}
+}
+
+func TestVoicesService_ListFilterEncoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    *VoiceListParams
+		wantQuery string
+		wantName  string
+	}{
+		{
+			name:      "name with spaces",
+			params:    &VoiceListParams{Name: stringPtr("Deep Narrator")},
+			wantQuery: "name=Deep+Narrator",
+			wantName:  "Deep Narrator",
+		},
+		{
+			name:      "non-ASCII name",
+			params:    &VoiceListParams{Name: stringPtr("Zoë & Renée")},
+			wantQuery: "name=Zo%C3%AB+%26+Ren%C3%A9e",
+			wantName:  "Zoë & Renée",
+		},
+		{
+			name:      "name and language",
+			params:    &VoiceListParams{Name: stringPtr("Émile"), Language: stringPtr("fr")},
+			wantQuery: "language=fr&name=%C3%89mile",
+			wantName:  "Émile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %q, got %q", tt.wantQuery, r.URL.RawQuery)
+				}
+				if got := r.URL.Query().Get("name"); got != tt.wantName {
+					t.Errorf("expected decoded name %q, got %q", tt.wantName, got)
+				}
+				json.NewEncoder(w).Encode([]Voice{})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if _, err := client.Voices.List(context.Background(), tt.params); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVoicesService_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/voices/" {
+			t.Errorf("expected path /voices/, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("name"); got != "Zoë Voice" {
+			t.Errorf("expected name filter %q, got %q", "Zoë Voice", got)
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"items":       []Voice{{UID: "v1", Name: "Zoë Voice"}},
+				"next_cursor": "c1",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode([]Voice{{UID: "v2", Name: "Zoë Voice 2"}})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voices, err := client.Voices.Search(context.Background(), "Zoë Voice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(voices) != 2 || voices[0].UID != "v1" || voices[1].UID != "v2" {
+		t.Errorf("expected voices from both pages, got %+v", voices)
+	}
+}
+
+func TestVoicesService_SearchOffsetPages(t *testing.T) {
+	voices := []Voice{{UID: "v1", Name: "Zoë"}, {UID: "v2", Name: "Zoë 2"}, {UID: "v3", Name: "Zoë 3"}}
+	server := httptest.NewServer(serveVoicesByOffset(voices, 2))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	got, err := client.Voices.Search(context.Background(), "Zoë")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, voices) {
+		t.Errorf("expected voices from both pages, got %+v", got)
+	}
+}
+
+func TestVoiceListParamsBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *VoiceListParamsBuilder
+		literal *VoiceListParams
+	}{
+		{
+			name:    "empty",
+			builder: NewVoiceListParams(),
+			literal: &VoiceListParams{},
+		},
+		{
+			name:    "skip and limit",
+			builder: NewVoiceListParams().Skip(10).Limit(5),
+			literal: &VoiceListParams{Skip: 10, Limit: 5},
+		},
+		{
+			name:    "all params",
+			builder: NewVoiceListParams().Skip(5).Limit(10).IncludeCatalog().Language("en").Name("Emma"),
+			literal: &VoiceListParams{Skip: 5, Limit: 10, IncludeCatalog: true, Language: stringPtr("en"), Name: stringPtr("Emma")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestURIs []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestURIs = append(requestURIs, r.RequestURI)
+				json.NewEncoder(w).Encode([]Voice{})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+			if _, err := client.Voices.List(context.Background(), tt.builder.Build()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := client.Voices.List(context.Background(), tt.literal); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if requestURIs[0] != requestURIs[1] {
+				t.Errorf("builder request %q differs from literal request %q", requestURIs[0], requestURIs[1])
+			}
+		})
+	}
+}
+
+func TestVoiceListParamsBuilderBuildCopies(t *testing.T) {
+	builder := NewVoiceListParams().Limit(5)
+	first := builder.Build()
+	builder.Limit(10)
+
+	if first.Limit != 5 {
+		t.Errorf("expected built params to be unaffected by later changes, got limit %d", first.Limit)
+	}
+}
+
 func TestVoicesService_Get(t *testing.T) {
 	desc := "Test voice"
 	lang := "en"
@@ -320,13 +599,19 @@ func TestVoicesService_Create(t *testing.T) {
 }
 
 func TestVoicesService_Update(t *testing.T) {
+	start := time.Now()
 	name := "Updated Name"
 	desc := "Updated description"
+	lang := "fr"
+	startS := 1.5
+	rank := 2.0
+	tags := []map[string]interface{}{{"category": "narration"}}
 
 	tests := []struct {
 		name         string
 		voiceUID     string
 		params       VoiceUpdateParams
+		expectedKeys []string
 		responseCode int
 		responseBody interface{}
 		expectedErr  bool
@@ -337,11 +622,14 @@ func TestVoicesService_Update(t *testing.T) {
 			params: VoiceUpdateParams{
 				Name: &name,
 			},
+			// Unset fields must not be sent, so the server keeps them
+			expectedKeys: []string{"name"},
 			responseCode: http.StatusOK,
 			responseBody: Voice{
-				UID:      "voice-123",
-				Name:     name,
-				Filename: "test.wav",
+				UID:         "voice-123",
+				Name:        name,
+				Description: &desc,
+				Filename:    "test.wav",
 			},
 			expectedErr: false,
 		},
@@ -352,12 +640,38 @@ func TestVoicesService_Update(t *testing.T) {
 				Name:        &name,
 				Description: &desc,
 			},
+			expectedKeys: []string{"description", "name"},
+			responseCode: http.StatusOK,
+			responseBody: Voice{
+				UID:         "voice-123",
+				Name:        name,
+				Description: &desc,
+				Filename:    "test.wav",
+			},
+			expectedErr: false,
+		},
+		{
+			name:     "update all fields",
+			voiceUID: "voice-123",
+			params: VoiceUpdateParams{
+				Name:        &name,
+				Description: &desc,
+				Language:    &lang,
+				StartS:      &startS,
+				Tags:        tags,
+				Rank:        &rank,
+			},
+			expectedKeys: []string{"description", "language", "name", "rank", "start_s", "tags"},
 			responseCode: http.StatusOK,
 			responseBody: Voice{
 				UID:         "voice-123",
 				Name:        name,
 				Description: &desc,
+				Language:    &lang,
+				StartS:      startS,
 				Filename:    "test.wav",
+				Tags:        tags,
+				Rank:        &rank,
 			},
 			expectedErr: false,
 		},
@@ -367,6 +681,7 @@ func TestVoicesService_Update(t *testing.T) {
 			params: VoiceUpdateParams{
 				Name: &name,
 			},
+			expectedKeys: []string{"name"},
 			responseCode: http.StatusNotFound,
 			responseBody: map[string]string{"detail": "Voice not found"},
 			expectedErr:  true,
@@ -385,14 +700,30 @@ func TestVoicesService_Update(t *testing.T) {
 					t.Errorf("expected method PUT, got %q", r.Method)
 				}
 
-				// Verify JSON body
+				// Verify JSON body only contains the fields that were set
 				var body map[string]interface{}
 				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 					t.Errorf("failed to decode request body: %v", err)
 				}
+				keys := make([]string, 0, len(body))
+				for k := range body {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				if !reflect.DeepEqual(keys, tt.expectedKeys) {
+					t.Errorf("expected body keys %v, got %v", tt.expectedKeys, keys)
+				}
+
+				response := tt.responseBody
+				if v, ok := response.(Voice); ok {
+					// The server stamps the voice when it is updated
+					now := time.Now()
+					v.UpdatedAt = &now
+					response = v
+				}
 
 				w.WriteHeader(tt.responseCode)
-				json.NewEncoder(w).Encode(tt.responseBody)
+				json.NewEncoder(w).Encode(response)
 			}))
 			defer server.Close()
 
@@ -417,13 +748,78 @@ func TestVoicesService_Update(t *testing.T) {
 			}
 
 			expected := tt.responseBody.(Voice)
+			if voice.UID != expected.UID {
+				t.Errorf("expected UID %q, got %q", expected.UID, voice.UID)
+			}
 			if voice.Name != expected.Name {
 				t.Errorf("expected Name %q, got %q", expected.Name, voice.Name)
 			}
+			if !reflect.DeepEqual(voice.Description, expected.Description) {
+				t.Errorf("expected Description %v, got %v", expected.Description, voice.Description)
+			}
+			if !reflect.DeepEqual(voice.Language, expected.Language) {
+				t.Errorf("expected Language %v, got %v", expected.Language, voice.Language)
+			}
+			if voice.StartS != expected.StartS {
+				t.Errorf("expected StartS %f, got %f", expected.StartS, voice.StartS)
+			}
+			if !reflect.DeepEqual(voice.Tags, expected.Tags) {
+				t.Errorf("expected Tags %v, got %v", expected.Tags, voice.Tags)
+			}
+			if !reflect.DeepEqual(voice.Rank, expected.Rank) {
+				t.Errorf("expected Rank %v, got %v", expected.Rank, voice.Rank)
+			}
+			if voice.UpdatedAt == nil {
+				t.Error("expected UpdatedAt to be set")
+			} else if voice.UpdatedAt.Before(start) {
+				t.Errorf("expected UpdatedAt after %v, got %v", start, *voice.UpdatedAt)
+			}
 		})
 	}
 }
 
+func TestVoicesService_UpdateNoFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("expected no request to be made")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	_, err := client.Voices.Update(context.Background(), "voice-123", VoiceUpdateParams{})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if validationErr.Error() != "validation error: at least one field must be set" {
+		t.Errorf("unexpected error message: %q", validationErr.Error())
+	}
+}
+
+func TestVoicesService_UpdateNameTooLong(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("expected no request to be made")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	_, err := client.Voices.Update(context.Background(), "voice-123", VoiceUpdateParams{
+		Name: stringPtr(strings.Repeat("a", 256)),
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if validationErr.Error() != "validation error: name must be at most 255 bytes" {
+		t.Errorf("unexpected error message: %q", validationErr.Error())
+	}
+}
+
 func TestVoicesService_Delete(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -545,7 +941,667 @@ func TestVoicesService_CreateWithReader(t *testing.T) {
 	}
 }
 
+func TestVoicesService_CreateInputFormatFromFilename(t *testing.T) {
+	tests := []struct {
+		filename    string
+		inputFormat string
+		expected    string
+	}{
+		{filename: "sample.wav", expected: "wav"},
+		{filename: "sample.pcm", expected: "pcm"},
+		{filename: "sample.opus", expected: "opus"},
+		{filename: "SAMPLE.WAV", expected: "wav"},
+		{filename: "sample.mp3", expected: ""},
+		{filename: "sample", expected: ""},
+		{filename: "sample.wav", inputFormat: "opus", expected: "opus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename+"/"+tt.inputFormat, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(10 << 20); err != nil {
+					t.Errorf("failed to parse form: %v", err)
+				}
+
+				_, sent := r.MultipartForm.Value["input_format"]
+				if tt.expected == "" && sent {
+					t.Errorf("expected no input_format, got %q", r.FormValue("input_format"))
+				}
+				if r.FormValue("input_format") != tt.expected {
+					t.Errorf("expected input_format %q, got %q", tt.expected, r.FormValue("input_format"))
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(VoiceCreateResponse{UID: stringPtr("voice-new")})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+			_, err := client.Voices.Create(context.Background(), strings.NewReader("audio"), tt.filename, VoiceCreateParams{
+				Name:        "Voice",
+				InputFormat: tt.inputFormat,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVoicesService_CreateValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		params   VoiceCreateParams
+		wantLoc  string
+	}{
+		{
+			name:     "empty filename",
+			filename: "",
+			params:   VoiceCreateParams{Name: "Voice"},
+			wantLoc:  "filename",
+		},
+		{
+			name:     "empty name",
+			filename: "sample.wav",
+			params:   VoiceCreateParams{},
+			wantLoc:  "name",
+		},
+		{
+			name:     "name too long",
+			filename: "sample.wav",
+			params:   VoiceCreateParams{Name: strings.Repeat("a", 256)},
+			wantLoc:  "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				t.Error("expected no request to be made")
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+			_, err := client.Voices.Create(context.Background(), strings.NewReader("audio"), tt.filename, tt.params)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if len(validationErr.Errors) != 1 {
+				t.Fatalf("expected 1 error detail, got %d", len(validationErr.Errors))
+			}
+			if loc := validationErr.Errors[0].Loc; len(loc) != 1 || loc[0] != tt.wantLoc {
+				t.Errorf("expected loc [%q], got %v", tt.wantLoc, loc)
+			}
+		})
+	}
+}
+
+func TestVoicesService_CreateCancelMidUpload(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 1024)
+		_, _ = io.ReadFull(r.Body, buf)
+		close(started)
+		// Keep reading until the client goes away
+		_, _ = io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	audio := &endlessAudio{done: make(chan error, 1)}
+	_, err := client.Voices.Create(ctx, audio, "large.wav", VoiceCreateParams{Name: "Large"})
+	if err == nil {
+		t.Fatal("expected error after cancellation")
+	}
+
+	select {
+	case err := <-audio.done:
+		if !errors.Is(err, io.ErrClosedPipe) && !errors.Is(err, context.Canceled) {
+			t.Errorf("expected closed pipe error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("upload goroutine still writing after cancellation")
+	}
+}
+
+func TestVoicesService_CreateTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTimeout(50*time.Millisecond))
+
+	_, err := client.Voices.Create(context.Background(), strings.NewReader("audio"), "sample.wav", VoiceCreateParams{Name: "Voice"})
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Errorf("expected TimeoutError, got %T: %v", err, err)
+	}
+}
+
+// endlessAudio writes audio until its destination fails and reports the
+// write error on done.
+type endlessAudio struct {
+	done chan error
+}
+
+func (a *endlessAudio) Read(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (a *endlessAudio) WriteTo(w io.Writer) (int64, error) {
+	chunk := make([]byte, 32*1024)
+	var total int64
+	for {
+		n, err := w.Write(chunk)
+		total += int64(n)
+		if err != nil {
+			a.done <- err
+			return total, err
+		}
+	}
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestVoicesService_ListCursorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("cursor"); got != "abc" {
+			t.Errorf("expected cursor=abc, got %q", got)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":       []Voice{{UID: "voice-3", Name: "Three"}},
+			"next_cursor": "def",
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+	page, err := client.Voices.ListPage(context.Background(), NewVoiceListParams().Cursor("abc").Build())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Voices) != 1 || page.Voices[0].UID != "voice-3" {
+		t.Errorf("unexpected voices: %+v", page.Voices)
+	}
+	if page.NextCursor == nil || *page.NextCursor != "def" {
+		t.Errorf("expected next cursor 'def', got %v", page.NextCursor)
+	}
+	if !page.HasMore || page.Total != -1 {
+		t.Errorf("expected more voices of unknown total, got HasMore=%v Total=%d", page.HasMore, page.Total)
+	}
+
+	// List keeps returning the bare voices
+	voices, err := client.Voices.List(context.Background(), &VoiceListParams{Cursor: stringPtr("abc")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(voices) != 1 {
+		t.Errorf("expected 1 voice, got %d", len(voices))
+	}
+}
+
+// serveVoicesByOffset returns a handler that pages voices by skip and limit
+// the way offset-paginated servers do: 206 Partial Content with a
+// Content-Range header while voices remain, and a bare array body.
+func serveVoicesByOffset(voices []Voice, defaultLimit int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			limit = defaultLimit
+		}
+		end := min(skip+limit, len(voices))
+		skip = min(skip, end)
+		if end < len(voices) {
+			w.Header().Set("Content-Range", fmt.Sprintf("voices %d-%d/%d", skip, end-1, len(voices)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		json.NewEncoder(w).Encode(voices[skip:end])
+	}
+}
+
+func TestVoicesService_Iter(t *testing.T) {
+	pages := map[string]map[string]interface{}{
+		"":   {"items": []Voice{{UID: "v1"}, {UID: "v2"}}, "next_cursor": "c1"},
+		"c1": {"items": []Voice{}, "next_cursor": "c2"},
+		"c2": {"items": []Voice{{UID: "v3"}}, "next_cursor": nil},
+	}
+
+	tests := []struct {
+		name     string
+		handler  func(w http.ResponseWriter, r *http.Request)
+		wantUIDs []string
+		wantErr  bool
+	}{
+		{
+			name: "follows cursors until nil",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("skip") != "" && r.URL.Query().Get("cursor") != "" {
+					t.Errorf("skip sent alongside cursor: %s", r.URL.RawQuery)
+				}
+				json.NewEncoder(w).Encode(pages[r.URL.Query().Get("cursor")])
+			},
+			wantUIDs: []string{"v1", "v2", "v3"},
+		},
+		{
+			name:     "advances skip over 206 pages",
+			handler:  serveVoicesByOffset([]Voice{{UID: "v0"}, {UID: "v1"}, {UID: "v2"}, {UID: "v3"}, {UID: "v4"}, {UID: "v5"}, {UID: "v6"}, {UID: "v7"}, {UID: "v8"}}, 2),
+			wantUIDs: []string{"v5", "v6", "v7", "v8"},
+		},
+		{
+			name: "bare array is a single page",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				json.NewEncoder(w).Encode([]Voice{{UID: "v1"}})
+			},
+			wantUIDs: []string{"v1"},
+		},
+		{
+			name: "error on later page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("cursor") == "c1" {
+					w.WriteHeader(http.StatusUnauthorized)
+					json.NewEncoder(w).Encode(map[string]string{"detail": "Invalid API key"})
+					return
+				}
+				json.NewEncoder(w).Encode(pages[""])
+			},
+			wantUIDs: []string{"v1", "v2"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.handler))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+
+			it := client.Voices.Iter(context.Background(), &VoiceListParams{Skip: 5, Limit: 2})
+			var uids []string
+			for it.Next() {
+				uids = append(uids, it.Voice().UID)
+			}
+
+			if !reflect.DeepEqual(uids, tt.wantUIDs) {
+				t.Errorf("expected %v, got %v", tt.wantUIDs, uids)
+			}
+			if (it.Err() != nil) != tt.wantErr {
+				t.Errorf("unexpected error state: %v", it.Err())
+			}
+			if it.Next() {
+				t.Error("expected Next to stay false once exhausted")
+			}
+		})
+	}
+}
+
+func TestVoicesService_Preview(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		status     int
+		wantAudio  string
+		wantErr    bool
+		wantCalled bool
+	}{
+		{name: "preview endpoint", text: "Hello there", status: http.StatusOK, wantAudio: "preview-audio", wantCalled: true},
+		{name: "not found falls back to TTS", text: "Hello there", status: http.StatusNotFound, wantAudio: "Hello there", wantCalled: true},
+		{name: "method not allowed falls back to TTS", text: "Hello there", status: http.StatusMethodNotAllowed, wantAudio: "Hello there", wantCalled: true},
+		{name: "bad request", text: "Hello there", status: http.StatusBadRequest, wantErr: true, wantCalled: true},
+		{name: "text too long", text: strings.Repeat("é", maxPreviewTextLen+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if r.Method != http.MethodPost || r.URL.Path != "/voices/v1/preview" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				var body map[string]string
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["text"] != tt.text || body["output_format"] != string(FormatWAV) {
+					t.Errorf("unexpected body %v", body)
+				}
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					w.Write([]byte("preview-audio"))
+				} else {
+					w.Write([]byte(`{"detail": "nope"}`))
+				}
+			}))
+			defer server.Close()
+
+			var peak atomic.Int32
+			wsServer := newEchoTTSServer(t, &peak)
+			defer wsServer.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			client.wsURL = "ws" + strings.TrimPrefix(wsServer.URL, "http")
+
+			result, err := client.Voices.Preview(context.Background(), "v1", tt.text, FormatWAV)
+			if called != tt.wantCalled {
+				t.Errorf("expected preview endpoint called=%v, got %v", tt.wantCalled, called)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(result.RawData) != tt.wantAudio {
+				t.Errorf("expected audio %q, got %q", tt.wantAudio, result.RawData)
+			}
+		})
+	}
+}
+
+func TestVoicesService_DeleteBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		wantPeak    int32
+	}{
+		{name: "default concurrency", concurrency: 0, wantPeak: defaultVoicesBatchConcurrency},
+		{name: "custom concurrency", concurrency: 2, wantPeak: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var open, peak atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := open.Add(1)
+				defer open.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+
+				if r.Method != http.MethodDelete {
+					t.Errorf("expected DELETE, got %s", r.Method)
+				}
+				if r.URL.Path == "/voices/missing" {
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"detail": "voice not found"}`))
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithVoicesBatchConcurrency(tt.concurrency))
+			uids := []string{"v0", "v1", "missing", "v3", "v4", "v5", "v6", "v7"}
+			errs := client.Voices.DeleteBatch(context.Background(), uids)
+
+			if len(errs) != len(uids) {
+				t.Fatalf("expected %d errors, got %d", len(uids), len(errs))
+			}
+			for i, err := range errs {
+				if uids[i] == "missing" {
+					if !errors.Is(err, ErrNotFound) {
+						t.Errorf("index %d: expected NotFoundError, got %v", i, err)
+					}
+				} else if err != nil {
+					t.Errorf("index %d: unexpected error: %v", i, err)
+				}
+			}
+			if got := peak.Load(); got != tt.wantPeak {
+				t.Errorf("expected peak concurrency %d, got %d", tt.wantPeak, got)
+			}
+		})
+	}
+}
+
+func TestVoicesService_UpdateBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uid := strings.TrimPrefix(r.URL.Path, "/voices/")
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if uid == "bad" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail": "voice not found"}`))
+			return
+		}
+		var params VoiceUpdateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		json.NewEncoder(w).Encode(Voice{UID: uid, Name: *params.Name})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	voices, errs := client.Voices.UpdateBatch(context.Background(), []VoiceBatchUpdate{
+		{UID: "v1", VoiceUpdateParams: VoiceUpdateParams{Name: stringPtr("One")}},
+		{UID: "bad", VoiceUpdateParams: VoiceUpdateParams{Name: stringPtr("Two")}},
+		{UID: "v3", VoiceUpdateParams: VoiceUpdateParams{}},
+		{UID: "v4", VoiceUpdateParams: VoiceUpdateParams{Name: stringPtr("Four")}},
+	})
+
+	if errs[0] != nil || voices[0].UID != "v1" || voices[0].Name != "One" {
+		t.Errorf("index 0: expected updated voice, got %+v, %v", voices[0], errs[0])
+	}
+	if !errors.Is(errs[1], ErrNotFound) || voices[1].UID != "" {
+		t.Errorf("index 1: expected NotFoundError and zero voice, got %+v, %v", voices[1], errs[1])
+	}
+	if !errors.Is(errs[2], ErrValidation) {
+		t.Errorf("index 2: expected ValidationError for empty update, got %v", errs[2])
+	}
+	if errs[3] != nil || voices[3].Name != "Four" {
+		t.Errorf("index 3: expected updated voice, got %+v, %v", voices[3], errs[3])
+	}
+}
+
+func TestVoicesService_GetByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    [][]Voice
+		byOffset bool
+		wantUID  string
+		wantErr  func(error) bool
+	}{
+		{
+			name:    "one match on a later page",
+			pages:   [][]Voice{{{UID: "v1", Name: "Emma Narrator"}}, {{UID: "v2", Name: "Emma"}}},
+			wantUID: "v2",
+		},
+		{
+			name:     "one match on a later offset page",
+			pages:    [][]Voice{{{UID: "v1", Name: "Emma Narrator"}}, {{UID: "v2", Name: "Emma"}}},
+			byOffset: true,
+			wantUID:  "v2",
+		},
+		{
+			name:    "no exact match",
+			pages:   [][]Voice{{{UID: "v1", Name: "Emma Narrator"}}, {{UID: "v2", Name: "emma"}}},
+			wantErr: func(err error) bool { return errors.Is(err, ErrNotFound) },
+		},
+		{
+			name:  "multiple matches",
+			pages: [][]Voice{{{UID: "v1", Name: "Emma"}}, {{UID: "v2", Name: "Emma"}}},
+			wantErr: func(err error) bool {
+				return err != nil && err.Error() == "multiple voices found with name Emma; use Get(uid) instead"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("name"); got != "Emma" {
+					t.Errorf("expected name filter Emma, got %q", got)
+				}
+				if tt.byOffset {
+					serveVoicesByOffset(slices.Concat(tt.pages...), len(tt.pages[0]))(w, r)
+					return
+				}
+				page := 0
+				if r.URL.Query().Get("cursor") != "" {
+					page = 1
+				}
+				body := map[string]interface{}{"items": tt.pages[page]}
+				if page+1 < len(tt.pages) {
+					body["next_cursor"] = "c1"
+				}
+				json.NewEncoder(w).Encode(body)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			voice, err := client.Voices.GetByName(context.Background(), "Emma")
+
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if voice.UID != tt.wantUID {
+				t.Errorf("expected voice %s, got %s", tt.wantUID, voice.UID)
+			}
+		})
+	}
+}
+
+func TestVoicesService_CreateFromURL(t *testing.T) {
+	const audio = "remote audio bytes"
+
+	const (
+		audioFileMissing = `{"detail": [{"loc": ["body", "audio_file"], "msg": "field required", "type": "missing"}]}`
+		audioURLRejected = `{"detail": [{"loc": ["body", "audio_url"], "msg": "extra fields not permitted", "type": "extra_forbidden"}]}`
+		badLanguage      = `{"detail": [{"loc": ["body", "language"], "msg": "unsupported language", "type": "value_error"}]}`
+	)
+
+	tests := []struct {
+		name          string
+		audioURLPath  string
+		jsonStatus    int
+		jsonError     string
+		wantDownloads int32
+		wantUploads   int32
+		wantErr       string
+	}{
+		{name: "server fetches the URL", audioURLPath: "/sample.wav", jsonStatus: http.StatusCreated},
+		{name: "fallback when audio_file is required", audioURLPath: "/sample.wav", jsonStatus: http.StatusUnprocessableEntity, jsonError: audioFileMissing, wantDownloads: 1, wantUploads: 1},
+		{name: "fallback when audio_url is rejected", audioURLPath: "/sample.wav", jsonStatus: http.StatusUnprocessableEntity, jsonError: audioURLRejected, wantDownloads: 1, wantUploads: 1},
+		{name: "other validation errors do not fall back", audioURLPath: "/sample.wav", jsonStatus: http.StatusUnprocessableEntity, jsonError: badLanguage, wantErr: "*gradium.ValidationError"},
+		{name: "download fails", audioURLPath: "/missing.wav", jsonStatus: http.StatusUnprocessableEntity, jsonError: audioFileMissing, wantDownloads: 1, wantErr: "*gradium.Error"},
+		{name: "other errors do not fall back", audioURLPath: "/sample.wav", jsonStatus: http.StatusUnauthorized, wantErr: "*gradium.AuthenticationError"},
+		{name: "missing URL", jsonStatus: http.StatusCreated, wantErr: "*gradium.ValidationError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var downloads, uploads atomic.Int32
+			var serverURL string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/sample.wav":
+					downloads.Add(1)
+					if r.Header.Get("x-api-key") != "" || r.Header.Get("Authorization") != "" {
+						t.Error("expected the download not to carry the API key or middleware headers")
+					}
+					w.Write([]byte(audio))
+
+				case r.URL.Path == "/missing.wav":
+					downloads.Add(1)
+					w.WriteHeader(http.StatusForbidden)
+
+				case r.Header.Get("Content-Type") == "application/json":
+					var body voiceCreateURLRequest
+					json.NewDecoder(r.Body).Decode(&body)
+					if body.AudioURL != serverURL+tt.audioURLPath || body.Name != "Remote" || body.InputFormat != "wav" {
+						t.Errorf("unexpected JSON body %+v", body)
+					}
+					w.WriteHeader(tt.jsonStatus)
+					switch tt.jsonStatus {
+					case http.StatusCreated:
+						json.NewEncoder(w).Encode(VoiceCreateResponse{UID: stringPtr("voice-url")})
+					case http.StatusUnprocessableEntity:
+						w.Write([]byte(tt.jsonError))
+					}
+
+				default:
+					uploads.Add(1)
+					file, _, err := r.FormFile("audio_file")
+					if err != nil {
+						t.Errorf("failed to get audio file: %v", err)
+						return
+					}
+					defer file.Close()
+					if content, _ := io.ReadAll(file); string(content) != audio {
+						t.Errorf("expected uploaded audio %q, got %q", audio, content)
+					}
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(VoiceCreateResponse{UID: stringPtr("voice-upload")})
+				}
+			}))
+			defer server.Close()
+			serverURL = server.URL
+
+			addAuth := func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer secret")
+				return next.RoundTrip(req)
+			}
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithMiddleware(addAuth))
+
+			audioURL := ""
+			if tt.audioURLPath != "" {
+				audioURL = server.URL + tt.audioURLPath
+			}
+			result, err := client.Voices.CreateFromURL(context.Background(), audioURL, "sample.wav", VoiceCreateParams{Name: "Remote"})
+
+			if tt.wantErr != "" {
+				if got := getErrorTypeName(err); got != tt.wantErr {
+					t.Errorf("expected %s, got %s (%v)", tt.wantErr, got, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if result.UID == nil {
+				t.Error("expected a UID")
+			}
+
+			if got := downloads.Load(); got != tt.wantDownloads {
+				t.Errorf("expected %d downloads, got %d", tt.wantDownloads, got)
+			}
+			if got := uploads.Load(); got != tt.wantUploads {
+				t.Errorf("expected %d uploads, got %d", tt.wantUploads, got)
+			}
+		})
+	}
+}